@@ -0,0 +1,322 @@
+package gocloak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AdminEventResourceType is an enum type for the resource types of admin events
+type AdminEventResourceType string
+
+// AdminEventResourceType values
+const (
+	AdminEventResourceTypeRealm                       AdminEventResourceType = "REALM"
+	AdminEventResourceTypeRealmRole                   AdminEventResourceType = "REALM_ROLE"
+	AdminEventResourceTypeRealmRoleMapping            AdminEventResourceType = "REALM_ROLE_MAPPING"
+	AdminEventResourceTypeRealmScopeMapping           AdminEventResourceType = "REALM_SCOPE_MAPPING"
+	AdminEventResourceTypeAuthFlow                    AdminEventResourceType = "AUTH_FLOW"
+	AdminEventResourceTypeAuthExecutionFlow           AdminEventResourceType = "AUTH_EXECUTION_FLOW"
+	AdminEventResourceTypeAuthExecution               AdminEventResourceType = "AUTH_EXECUTION"
+	AdminEventResourceTypeAuthenticatorConfig         AdminEventResourceType = "AUTHENTICATOR_CONFIG"
+	AdminEventResourceTypeRequiredAction              AdminEventResourceType = "REQUIRED_ACTION"
+	AdminEventResourceTypeIdentityProvider            AdminEventResourceType = "IDENTITY_PROVIDER"
+	AdminEventResourceTypeIdentityProviderMapper      AdminEventResourceType = "IDENTITY_PROVIDER_MAPPER"
+	AdminEventResourceTypeProtocolMapper              AdminEventResourceType = "PROTOCOL_MAPPER"
+	AdminEventResourceTypeUser                        AdminEventResourceType = "USER"
+	AdminEventResourceTypeUserLoginFailure            AdminEventResourceType = "USER_LOGIN_FAILURE"
+	AdminEventResourceTypeUserSession                 AdminEventResourceType = "USER_SESSION"
+	AdminEventResourceTypeUserFederationProvider      AdminEventResourceType = "USER_FEDERATION_PROVIDER"
+	AdminEventResourceTypeUserFederationMapper        AdminEventResourceType = "USER_FEDERATION_MAPPER"
+	AdminEventResourceTypeGroup                       AdminEventResourceType = "GROUP"
+	AdminEventResourceTypeGroupMembership             AdminEventResourceType = "GROUP_MEMBERSHIP"
+	AdminEventResourceTypeClient                      AdminEventResourceType = "CLIENT"
+	AdminEventResourceTypeClientInitialAccessModel    AdminEventResourceType = "CLIENT_INITIAL_ACCESS_MODEL"
+	AdminEventResourceTypeClientRole                  AdminEventResourceType = "CLIENT_ROLE"
+	AdminEventResourceTypeClientRoleMapping           AdminEventResourceType = "CLIENT_ROLE_MAPPING"
+	AdminEventResourceTypeClientScope                 AdminEventResourceType = "CLIENT_SCOPE"
+	AdminEventResourceTypeClientScopeMapping          AdminEventResourceType = "CLIENT_SCOPE_MAPPING"
+	AdminEventResourceTypeClientScopeClientMapping    AdminEventResourceType = "CLIENT_SCOPE_CLIENT_MAPPING"
+	AdminEventResourceTypeClusterNode                 AdminEventResourceType = "CLUSTER_NODE"
+	AdminEventResourceTypeComponent                   AdminEventResourceType = "COMPONENT"
+	AdminEventResourceTypeAuthorizationResourceServer AdminEventResourceType = "AUTHORIZATION_RESOURCE_SERVER"
+	AdminEventResourceTypeAuthorizationResource       AdminEventResourceType = "AUTHORIZATION_RESOURCE"
+	AdminEventResourceTypeAuthorizationScope          AdminEventResourceType = "AUTHORIZATION_SCOPE"
+	AdminEventResourceTypeAuthorizationPolicy         AdminEventResourceType = "AUTHORIZATION_POLICY"
+	AdminEventResourceTypeCustom                      AdminEventResourceType = "CUSTOM"
+	AdminEventResourceTypeUserProfile                 AdminEventResourceType = "USER_PROFILE"
+	AdminEventResourceTypeOrganization                AdminEventResourceType = "ORGANIZATION"
+	AdminEventResourceTypeOrganizationMembership      AdminEventResourceType = "ORGANIZATION_MEMBERSHIP"
+)
+
+// AdminEventOperationType is an enum type for the operation types of admin events
+type AdminEventOperationType string
+
+// AdminEventOperationType values
+const (
+	AdminEventOperationTypeCreate AdminEventOperationType = "CREATE"
+	AdminEventOperationTypeUpdate AdminEventOperationType = "UPDATE"
+	AdminEventOperationTypeDelete AdminEventOperationType = "DELETE"
+	AdminEventOperationTypeAction AdminEventOperationType = "ACTION"
+)
+
+// AdminEventResourcePath holds the ids contained in the resource path of an admin event.
+// Ids which are not part of the path are left empty, e.g. "users/{id}/role-mappings/clients/{client}"
+// sets UserID and ClientID.
+type AdminEventResourcePath struct {
+	// Segments of the path, e.g. ["users", "{id}", "role-mappings", "clients", "{client}"]
+	Segments []string
+
+	UserID                string
+	GroupID               string
+	ClientID              string
+	RoleID                string
+	RoleName              string
+	ClientScopeID         string
+	IdentityProviderAlias string
+	MapperID              string
+	ComponentID           string
+	FlowID                string
+	FlowAlias             string
+	ExecutionID           string
+	AuthenticatorConfigID string
+	RequiredActionAlias   string
+	OrganizationID        string
+	ResourceID            string
+	ScopeID               string
+	PolicyID              string
+	PolicyType            string
+	SessionID             string
+}
+
+// adminEventPathIDs maps the collection segments of a resource path to the field holding the id which follows
+var adminEventPathIDs = map[string]func(path *AdminEventResourcePath) *string{
+	"users":                  func(p *AdminEventResourcePath) *string { return &p.UserID },
+	"members":                func(p *AdminEventResourcePath) *string { return &p.UserID },
+	"groups":                 func(p *AdminEventResourcePath) *string { return &p.GroupID },
+	"clients":                func(p *AdminEventResourcePath) *string { return &p.ClientID },
+	"roles-by-id":            func(p *AdminEventResourcePath) *string { return &p.RoleID },
+	"roles":                  func(p *AdminEventResourcePath) *string { return &p.RoleName },
+	"client-scopes":          func(p *AdminEventResourcePath) *string { return &p.ClientScopeID },
+	"default-client-scopes":  func(p *AdminEventResourcePath) *string { return &p.ClientScopeID },
+	"optional-client-scopes": func(p *AdminEventResourcePath) *string { return &p.ClientScopeID },
+	"instances":              func(p *AdminEventResourcePath) *string { return &p.IdentityProviderAlias },
+	"models":                 func(p *AdminEventResourcePath) *string { return &p.MapperID },
+	"mappers":                func(p *AdminEventResourcePath) *string { return &p.MapperID },
+	"components":             func(p *AdminEventResourcePath) *string { return &p.ComponentID },
+	"flows":                  func(p *AdminEventResourcePath) *string { return &p.FlowID },
+	"executions":             func(p *AdminEventResourcePath) *string { return &p.ExecutionID },
+	"config":                 func(p *AdminEventResourcePath) *string { return &p.AuthenticatorConfigID },
+	"required-actions":       func(p *AdminEventResourcePath) *string { return &p.RequiredActionAlias },
+	"organizations":          func(p *AdminEventResourcePath) *string { return &p.OrganizationID },
+	"resource":               func(p *AdminEventResourcePath) *string { return &p.ResourceID },
+	"scope":                  func(p *AdminEventResourcePath) *string { return &p.ScopeID },
+	"policy":                 func(p *AdminEventResourcePath) *string { return &p.PolicyID },
+	"permission":             func(p *AdminEventResourcePath) *string { return &p.PolicyID },
+	"sessions":               func(p *AdminEventResourcePath) *string { return &p.SessionID },
+}
+
+// adminEventPolicySubresources are the segments which may follow the id of a policy or permission
+var adminEventPolicySubresources = map[string]bool{
+	"associatedPolicies": true,
+	"dependentPolicies":  true,
+	"resources":          true,
+	"scopes":             true,
+}
+
+// ParseAdminEventResourcePath parses the resource path of an admin event
+func ParseAdminEventResourcePath(resourcePath string) AdminEventResourcePath {
+	var path AdminEventResourcePath
+	for _, segment := range strings.Split(resourcePath, "/") {
+		if segment != "" {
+			path.Segments = append(path.Segments, segment)
+		}
+	}
+
+	for i := 0; i+1 < len(path.Segments); i++ {
+		// flows/{alias}/executions/... and flows/{alias}/copy address the flow by its alias
+		if path.Segments[i] == "flows" && i+2 < len(path.Segments) {
+			path.FlowAlias = path.Segments[i+1]
+			i += 2
+			continue
+		}
+		// policy/{type}/{id} and permission/{type}/{id} address a policy through its type
+		if (path.Segments[i] == "policy" || path.Segments[i] == "permission") &&
+			i+2 < len(path.Segments) && !adminEventPolicySubresources[path.Segments[i+2]] {
+			path.PolicyType = path.Segments[i+1]
+			path.PolicyID = path.Segments[i+2]
+			i += 2
+			continue
+		}
+		id, ok := adminEventPathIDs[path.Segments[i]]
+		if !ok {
+			continue
+		}
+		*id(&path) = path.Segments[i+1]
+		i++
+	}
+
+	return path
+}
+
+// adminEventRepresentations returns a new value of the model matching the representation of a resource type
+var adminEventRepresentations = map[AdminEventResourceType]func() any{
+	AdminEventResourceTypeRealm:                       func() any { return &RealmRepresentation{} },
+	AdminEventResourceTypeRealmRole:                   func() any { return &Role{} },
+	AdminEventResourceTypeRealmRoleMapping:            func() any { return &[]*Role{} },
+	AdminEventResourceTypeRealmScopeMapping:           func() any { return &[]*Role{} },
+	AdminEventResourceTypeAuthFlow:                    func() any { return &AuthenticationFlowRepresentation{} },
+	AdminEventResourceTypeAuthExecutionFlow:           func() any { return &CreateAuthenticationExecutionFlowRepresentation{} },
+	AdminEventResourceTypeAuthExecution:               func() any { return &ModifyAuthenticationExecutionRepresentation{} },
+	AdminEventResourceTypeAuthenticatorConfig:         func() any { return &AuthenticatorConfigRepresentation{} },
+	AdminEventResourceTypeRequiredAction:              func() any { return &RequiredActionProviderRepresentation{} },
+	AdminEventResourceTypeIdentityProvider:            func() any { return &IdentityProviderRepresentation{} },
+	AdminEventResourceTypeIdentityProviderMapper:      func() any { return &IdentityProviderMapper{} },
+	AdminEventResourceTypeProtocolMapper:              func() any { return &ProtocolMapperRepresentation{} },
+	AdminEventResourceTypeUser:                        func() any { return &User{} },
+	AdminEventResourceTypeGroup:                       func() any { return &Group{} },
+	AdminEventResourceTypeGroupMembership:             func() any { return &Group{} },
+	AdminEventResourceTypeClient:                      func() any { return &Client{} },
+	AdminEventResourceTypeClientInitialAccessModel:    func() any { return &ClientInitialAccessCreatePresentation{} },
+	AdminEventResourceTypeClientRole:                  func() any { return &Role{} },
+	AdminEventResourceTypeClientRoleMapping:           func() any { return &[]*Role{} },
+	AdminEventResourceTypeClientScope:                 func() any { return &ClientScope{} },
+	AdminEventResourceTypeClientScopeMapping:          func() any { return &[]*Role{} },
+	AdminEventResourceTypeClientScopeClientMapping:    func() any { return &[]*Role{} },
+	AdminEventResourceTypeComponent:                   func() any { return &Component{} },
+	AdminEventResourceTypeAuthorizationResourceServer: func() any { return &ResourceServerRepresentation{} },
+	AdminEventResourceTypeAuthorizationResource:       func() any { return &ResourceRepresentation{} },
+	AdminEventResourceTypeAuthorizationScope:          func() any { return &ScopeRepresentation{} },
+	AdminEventResourceTypeAuthorizationPolicy:         func() any { return &PolicyRepresentation{} },
+	AdminEventResourceTypeUserProfile:                 func() any { return &UserProfileConfig{} },
+	AdminEventResourceTypeOrganization:                func() any { return &OrganizationRepresentation{} },
+	AdminEventResourceTypeOrganizationMembership:      func() any { return &MemberRepresentation{} },
+}
+
+// DecodeAdminEventRepresentation decodes the representation of an admin event into the model matching its
+// resource type, e.g. *User for USER events or *[]*Role for role mapping events.
+// The representation of resource types without a matching model is returned as json.RawMessage.
+// It returns nil if the event has no representation, which is the case for most DELETE events
+// and if the realm does not include the representation in admin events.
+func DecodeAdminEventRepresentation(event *AdminEventRepresentation) (any, error) {
+	if event == nil || NilOrEmpty(event.Representation) {
+		return nil, nil
+	}
+
+	newRepresentation, ok := adminEventRepresentations[AdminEventResourceType(PString(event.ResourceType))]
+	if !ok {
+		return json.RawMessage(*event.Representation), nil
+	}
+
+	representation := newRepresentation()
+	if err := json.Unmarshal([]byte(*event.Representation), representation); err != nil {
+		return nil, fmt.Errorf("could not decode representation of %s admin event: %w", PString(event.ResourceType), err)
+	}
+
+	return representation, nil
+}
+
+// DecodedAdminEvent is an admin event together with its parsed resource path and decoded representation
+type DecodedAdminEvent struct {
+	Event          *AdminEventRepresentation
+	ResourceType   AdminEventResourceType
+	OperationType  AdminEventOperationType
+	ResourcePath   AdminEventResourcePath
+	Representation any
+}
+
+// DecodeAdminEvent parses the resource path and decodes the representation of an admin event
+func DecodeAdminEvent(event *AdminEventRepresentation) (*DecodedAdminEvent, error) {
+	if event == nil {
+		return nil, errors.New("event required")
+	}
+
+	representation, err := DecodeAdminEventRepresentation(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DecodedAdminEvent{
+		Event:          event,
+		ResourceType:   AdminEventResourceType(PString(event.ResourceType)),
+		OperationType:  AdminEventOperationType(PString(event.OperationType)),
+		ResourcePath:   ParseAdminEventResourcePath(PString(event.ResourcePath)),
+		Representation: representation,
+	}, nil
+}
+
+// AdminEventHandler handles a decoded admin event
+type AdminEventHandler func(ctx context.Context, event *DecodedAdminEvent) error
+
+type adminEventHandlerRegistration struct {
+	resourceType  AdminEventResourceType
+	operationType AdminEventOperationType
+	handler       AdminEventHandler
+}
+
+// AdminEventDispatcher decodes admin events and passes them to the handlers registered for their
+// resource type and operation type. It is safe for concurrent use.
+type AdminEventDispatcher struct {
+	mutex    sync.RWMutex
+	handlers []adminEventHandlerRegistration
+}
+
+// NewAdminEventDispatcher creates a new AdminEventDispatcher without handlers
+func NewAdminEventDispatcher() *AdminEventDispatcher {
+	return &AdminEventDispatcher{}
+}
+
+// Handle registers a handler for admin events of the given resource type and operation type.
+// An empty resource type or operation type matches all events.
+func (d *AdminEventDispatcher) Handle(resourceType AdminEventResourceType, operationType AdminEventOperationType, handler AdminEventHandler) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.handlers = append(d.handlers, adminEventHandlerRegistration{
+		resourceType:  resourceType,
+		operationType: operationType,
+		handler:       handler,
+	})
+}
+
+// Dispatch decodes the admin event and calls every matching handler in the order they were registered.
+// Events without a matching handler are not decoded. The errors of all handlers are joined.
+func (d *AdminEventDispatcher) Dispatch(ctx context.Context, event *AdminEventRepresentation) error {
+	if event == nil {
+		return errors.New("event required")
+	}
+
+	resourceType := AdminEventResourceType(PString(event.ResourceType))
+	operationType := AdminEventOperationType(PString(event.OperationType))
+
+	d.mutex.RLock()
+	var handlers []AdminEventHandler
+	for _, registration := range d.handlers {
+		if (registration.resourceType == "" || registration.resourceType == resourceType) &&
+			(registration.operationType == "" || registration.operationType == operationType) {
+			handlers = append(handlers, registration.handler)
+		}
+	}
+	d.mutex.RUnlock()
+
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	decoded, err := DecodeAdminEvent(event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, decoded); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
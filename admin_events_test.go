@@ -0,0 +1,210 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+func TestParseAdminEventResourcePath(t *testing.T) {
+	testCases := []struct {
+		resourcePath string
+		expected     gocloak.AdminEventResourcePath
+	}{
+		{
+			resourcePath: "users/u1",
+			expected:     gocloak.AdminEventResourcePath{Segments: []string{"users", "u1"}, UserID: "u1"},
+		},
+		{
+			resourcePath: "users/u1/role-mappings/clients/c1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"users", "u1", "role-mappings", "clients", "c1"},
+				UserID:   "u1",
+				ClientID: "c1",
+			},
+		},
+		{
+			resourcePath: "users/u1/groups/g1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"users", "u1", "groups", "g1"},
+				UserID:   "u1",
+				GroupID:  "g1",
+			},
+		},
+		{
+			resourcePath: "clients/c1/roles/admin",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"clients", "c1", "roles", "admin"},
+				ClientID: "c1",
+				RoleName: "admin",
+			},
+		},
+		{
+			resourcePath: "clients/c1/protocol-mappers/models/m1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"clients", "c1", "protocol-mappers", "models", "m1"},
+				ClientID: "c1",
+				MapperID: "m1",
+			},
+		},
+		{
+			resourcePath: "identity-provider/instances/google/mappers/m1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments:              []string{"identity-provider", "instances", "google", "mappers", "m1"},
+				IdentityProviderAlias: "google",
+				MapperID:              "m1",
+			},
+		},
+		{
+			resourcePath: "authentication/flows/browser/executions/execution",
+			expected: gocloak.AdminEventResourcePath{
+				Segments:  []string{"authentication", "flows", "browser", "executions", "execution"},
+				FlowAlias: "browser",
+			},
+		},
+		{
+			resourcePath: "authentication/flows/f1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"authentication", "flows", "f1"},
+				FlowID:   "f1",
+			},
+		},
+		{
+			resourcePath: "clients/c1/authz/resource-server/policy/p1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"clients", "c1", "authz", "resource-server", "policy", "p1"},
+				ClientID: "c1",
+				PolicyID: "p1",
+			},
+		},
+		{
+			resourcePath: "clients/c1/authz/resource-server/policy/role/p1",
+			expected: gocloak.AdminEventResourcePath{
+				Segments:   []string{"clients", "c1", "authz", "resource-server", "policy", "role", "p1"},
+				ClientID:   "c1",
+				PolicyID:   "p1",
+				PolicyType: "role",
+			},
+		},
+		{
+			resourcePath: "clients/c1/authz/resource-server/permission/scope/p1/associatedPolicies",
+			expected: gocloak.AdminEventResourcePath{
+				Segments:   []string{"clients", "c1", "authz", "resource-server", "permission", "scope", "p1", "associatedPolicies"},
+				ClientID:   "c1",
+				PolicyID:   "p1",
+				PolicyType: "scope",
+			},
+		},
+		{
+			resourcePath: "clients/c1/authz/resource-server/policy/p1/dependentPolicies",
+			expected: gocloak.AdminEventResourcePath{
+				Segments: []string{"clients", "c1", "authz", "resource-server", "policy", "p1", "dependentPolicies"},
+				ClientID: "c1",
+				PolicyID: "p1",
+			},
+		},
+		{
+			resourcePath: "",
+			expected:     gocloak.AdminEventResourcePath{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.resourcePath, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, gocloak.ParseAdminEventResourcePath(testCase.resourcePath))
+		})
+	}
+}
+
+func TestDecodeAdminEventRepresentation(t *testing.T) {
+	representation, err := gocloak.DecodeAdminEventRepresentation(&gocloak.AdminEventRepresentation{
+		ResourceType:   gocloak.StringP("USER"),
+		Representation: gocloak.StringP(`{"username":"test","enabled":true}`),
+	})
+	require.NoError(t, err)
+	user, ok := representation.(*gocloak.User)
+	require.True(t, ok)
+	assert.Equal(t, "test", gocloak.PString(user.Username))
+	assert.True(t, gocloak.PBool(user.Enabled))
+
+	representation, err = gocloak.DecodeAdminEventRepresentation(&gocloak.AdminEventRepresentation{
+		ResourceType:   gocloak.StringP("REALM_ROLE_MAPPING"),
+		Representation: gocloak.StringP(`[{"name":"r1"},{"name":"r2"}]`),
+	})
+	require.NoError(t, err)
+	roles, ok := representation.(*[]*gocloak.Role)
+	require.True(t, ok)
+	require.Len(t, *roles, 2)
+	assert.Equal(t, "r2", gocloak.PString((*roles)[1].Name))
+
+	representation, err = gocloak.DecodeAdminEventRepresentation(&gocloak.AdminEventRepresentation{
+		ResourceType:   gocloak.StringP("CUSTOM"),
+		Representation: gocloak.StringP(`{"a":1}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`{"a":1}`), representation)
+
+	representation, err = gocloak.DecodeAdminEventRepresentation(&gocloak.AdminEventRepresentation{
+		ResourceType: gocloak.StringP("USER"),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, representation)
+
+	_, err = gocloak.DecodeAdminEventRepresentation(&gocloak.AdminEventRepresentation{
+		ResourceType:   gocloak.StringP("GROUP"),
+		Representation: gocloak.StringP(`[]`),
+	})
+	assert.Error(t, err)
+}
+
+func TestAdminEventDispatcher(t *testing.T) {
+	dispatcher := gocloak.NewAdminEventDispatcher()
+
+	var calls []string
+	dispatcher.Handle(gocloak.AdminEventResourceTypeGroup, gocloak.AdminEventOperationTypeCreate,
+		func(_ context.Context, event *gocloak.DecodedAdminEvent) error {
+			group, ok := event.Representation.(*gocloak.Group)
+			require.True(t, ok)
+			calls = append(calls, "create group "+gocloak.PString(group.Name)+" "+event.ResourcePath.GroupID)
+			return nil
+		})
+	dispatcher.Handle(gocloak.AdminEventResourceTypeGroup, "",
+		func(_ context.Context, event *gocloak.DecodedAdminEvent) error {
+			calls = append(calls, "group "+string(event.OperationType))
+			return errors.New("group failed")
+		})
+	dispatcher.Handle("", gocloak.AdminEventOperationTypeDelete,
+		func(_ context.Context, event *gocloak.DecodedAdminEvent) error {
+			calls = append(calls, "delete "+string(event.ResourceType))
+			return nil
+		})
+
+	err := dispatcher.Dispatch(context.Background(), &gocloak.AdminEventRepresentation{
+		OperationType:  gocloak.StringP("CREATE"),
+		ResourceType:   gocloak.StringP("GROUP"),
+		ResourcePath:   gocloak.StringP("groups/g1"),
+		Representation: gocloak.StringP(`{"name":"test"}`),
+	})
+	assert.EqualError(t, err, "group failed")
+
+	err = dispatcher.Dispatch(context.Background(), &gocloak.AdminEventRepresentation{
+		OperationType: gocloak.StringP("DELETE"),
+		ResourceType:  gocloak.StringP("USER"),
+		ResourcePath:  gocloak.StringP("users/u1"),
+	})
+	assert.NoError(t, err)
+
+	err = dispatcher.Dispatch(context.Background(), &gocloak.AdminEventRepresentation{
+		OperationType: gocloak.StringP("UPDATE"),
+		ResourceType:  gocloak.StringP("CLIENT"),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"create group test g1", "group CREATE", "delete USER"}, calls)
+}
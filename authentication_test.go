@@ -0,0 +1,233 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+// authenticationFlowTestServer serves the executions of a single flow and moves them on raise and lower requests
+type authenticationFlowTestServer struct {
+	executions []string
+	calls      []string
+	stuck      string
+}
+
+func (s *authenticationFlowTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/authentication/flows/flow/executions") {
+		var executions []*gocloak.ModifyAuthenticationExecutionRepresentation
+		for _, id := range s.executions {
+			executions = append(executions, &gocloak.ModifyAuthenticationExecutionRepresentation{
+				ID:    gocloak.StringP(id),
+				Level: gocloak.IntP(0),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(executions)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if r.Method != http.MethodPost || len(parts) < 3 || parts[len(parts)-3] != "executions" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	id, action := parts[len(parts)-2], parts[len(parts)-1]
+	s.calls = append(s.calls, action+" "+id)
+
+	position := slices.Index(s.executions, id)
+	if id != s.stuck {
+		switch {
+		case action == "raise-priority" && position > 0:
+			s.executions[position-1], s.executions[position] = s.executions[position], s.executions[position-1]
+		case action == "lower-priority" && position < len(s.executions)-1:
+			s.executions[position], s.executions[position+1] = s.executions[position+1], s.executions[position]
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestOrderAuthenticationExecutions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		executions []string
+		order      []string
+		stuck      string
+		calls      []string
+		err        bool
+	}{
+		{
+			name:       "already ordered",
+			executions: []string{"a", "b", "c"},
+			order:      []string{"a", "b", "c"},
+		},
+		{
+			name:       "raise the last execution",
+			executions: []string{"a", "b", "c", "d"},
+			order:      []string{"d", "a", "b", "c"},
+			calls:      []string{"raise-priority d", "raise-priority d", "raise-priority d"},
+		},
+		{
+			name:       "lower the first execution",
+			executions: []string{"a", "b", "c", "d"},
+			order:      []string{"b", "c", "d", "a"},
+			calls:      []string{"lower-priority a", "lower-priority a", "lower-priority a"},
+		},
+		{
+			name:       "reverse",
+			executions: []string{"a", "b", "c"},
+			order:      []string{"c", "b", "a"},
+			calls:      []string{"raise-priority c", "raise-priority c", "raise-priority b"},
+		},
+		{
+			name:       "server order differs",
+			executions: []string{"a", "b", "c"},
+			order:      []string{"b", "a", "c"},
+			stuck:      "b",
+			calls:      []string{"raise-priority b"},
+			err:        true,
+		},
+		{
+			name:       "subset of the executions",
+			executions: []string{"a", "b", "c"},
+			order:      []string{"b", "a"},
+			err:        true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler := &authenticationFlowTestServer{executions: testCase.executions, stuck: testCase.stuck}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			client := gocloak.NewClient(server.URL)
+			err := client.OrderAuthenticationExecutions(context.Background(), "token", "realm", "flow", testCase.order)
+			if testCase.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.order, handler.executions)
+			}
+			assert.Equal(t, testCase.calls, handler.calls)
+		})
+	}
+}
+
+// requiredActionsTestServer serves required actions and swaps the priorities of neighbours on raise and lower
+// requests like Keycloak, which does not change their order if both have the same priority
+type requiredActionsTestServer struct {
+	requiredActions []*gocloak.RequiredActionProviderRepresentation
+	calls           []string
+}
+
+func (s *requiredActionsTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/authentication/required-actions") {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.requiredActions)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if r.Method != http.MethodPost || len(parts) < 3 || parts[len(parts)-3] != "required-actions" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	alias, action := parts[len(parts)-2], parts[len(parts)-1]
+	s.calls = append(s.calls, action+" "+alias)
+
+	slices.SortStableFunc(s.requiredActions, func(a, b *gocloak.RequiredActionProviderRepresentation) int {
+		return int(gocloak.PInt32(a.Priority) - gocloak.PInt32(b.Priority))
+	})
+	position := slices.IndexFunc(s.requiredActions, func(requiredAction *gocloak.RequiredActionProviderRepresentation) bool {
+		return gocloak.PString(requiredAction.Alias) == alias
+	})
+	neighbour := position - 1
+	if action == "lower-priority" {
+		neighbour = position + 1
+	}
+	if neighbour >= 0 && neighbour < len(s.requiredActions) {
+		a, b := s.requiredActions[position], s.requiredActions[neighbour]
+		a.Priority, b.Priority = b.Priority, a.Priority
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestOrderRequiredActions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		priorities []int32
+		aliases    []string
+		calls      []string
+		err        bool
+	}{
+		{
+			name:       "already ordered",
+			priorities: []int32{10, 20, 30, 40},
+			aliases:    []string{"a", "b"},
+		},
+		{
+			name:       "raise",
+			priorities: []int32{10, 20, 30, 40},
+			aliases:    []string{"d", "b"},
+			calls:      []string{"raise-priority d", "raise-priority d", "raise-priority d", "raise-priority b"},
+		},
+		{
+			name:       "lower",
+			priorities: []int32{10, 20, 30, 40},
+			aliases:    []string{"b", "c", "d"},
+			calls:      []string{"lower-priority a", "lower-priority a", "lower-priority a"},
+		},
+		{
+			name:       "same priority",
+			priorities: []int32{10, 10, 10, 10},
+			aliases:    []string{"b"},
+			calls:      []string{"raise-priority b"},
+			err:        true,
+		},
+		{
+			name:       "unknown required action",
+			priorities: []int32{10, 20, 30, 40},
+			aliases:    []string{"unknown"},
+			err:        true,
+		},
+		{
+			name:       "required action listed twice",
+			priorities: []int32{10, 20, 30, 40},
+			aliases:    []string{"b", "b"},
+			err:        true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handler := &requiredActionsTestServer{}
+			for i, alias := range []string{"a", "b", "c", "d"} {
+				handler.requiredActions = append(handler.requiredActions, &gocloak.RequiredActionProviderRepresentation{
+					Alias:    gocloak.StringP(alias),
+					Priority: gocloak.Int32P(testCase.priorities[i]),
+				})
+			}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			client := gocloak.NewClient(server.URL)
+			err := client.OrderRequiredActions(context.Background(), "token", "realm", testCase.aliases)
+			if testCase.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.calls, handler.calls)
+		})
+	}
+}
@@ -3,11 +3,16 @@ package gocloak
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -196,6 +201,21 @@ func getID(resp *resty.Response) string {
 	return parts[len(parts)-1]
 }
 
+// getInt64Attribute returns the attribute with the given name parsed as int64, or nil if it is not set or invalid
+func getInt64Attribute(attributes map[string]string, name string) *int64 {
+	value, ok := attributes[name]
+	if !ok {
+		return nil
+	}
+
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &result
+}
+
 func findUsedKey(usedKeyID string, keys []CertResponseKey) *CertResponseKey {
 	for _, key := range keys {
 		if key.Kid != nil && *key.Kid == usedKeyID {
@@ -664,6 +684,36 @@ func (g *GoCloak) DirectNakedImpersonationTokenExchange(ctx context.Context, cli
 	return g.GetToken(ctx, realm, tokenOptions)
 }
 
+// ImpersonateUser starts a browser session of the user with the given id on behalf of the admin owning the token.
+// It returns the url to redirect the browser to and the session cookies issued by Keycloak, which must be passed
+// on to the browser of the support engineer. The cookies are removed from the cookie jar of the client, so later
+// requests of the client are not sent with the session of the impersonated user.
+// The admin needs the "impersonation" role of the "realm-management" client of the realm, or of the "<realm>-realm"
+// client if the token was issued by the master realm, and the impersonation feature must not be disabled.
+func (g *GoCloak) ImpersonateUser(ctx context.Context, token, realm, userID string) (*ImpersonationRepresentation, error) {
+	const errMessage = "could not impersonate user"
+
+	var result ImpersonationRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Post(g.getAdminRealmURL(realm, "users", userID, "impersonation"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	result.Cookies = resp.Cookies()
+	if jar := g.restyClient.GetClient().Jar; jar != nil && len(result.Cookies) > 0 {
+		expired := make([]*http.Cookie, 0, len(result.Cookies))
+		for _, cookie := range result.Cookies {
+			expired = append(expired, &http.Cookie{Name: cookie.Name, Domain: cookie.Domain, Path: cookie.Path, MaxAge: -1})
+		}
+		jar.SetCookies(resp.RawResponse.Request.URL, expired)
+	}
+
+	return &result, nil
+}
+
 // LoginClientSignedJWT performs a login with client credentials and signed jwt claims
 func (g *GoCloak) LoginClientSignedJWT(
 	ctx context.Context,
@@ -758,6 +808,68 @@ func (g *GoCloak) LogoutAllSessions(ctx context.Context, accessToken, realm, use
 	return checkForError(resp, err, errMessage)
 }
 
+// GetUserConsents returns the consents and offline tokens the user granted, one entry per client
+func (g *GoCloak) GetUserConsents(ctx context.Context, accessToken, realm, userID string) ([]*UserConsentRepresentation, error) {
+	const errMessage = "could not get consents"
+
+	var result []*UserConsentRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "users", userID, "consents"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RevokeClientsConsents revokes the consents and offline tokens every user of the realm granted to the given clients,
+// e.g. when decommissioning applications. The clients are identified by their clientId.
+// It walks through all users of the realm and stops at the first error. It is safe to call it again after a failure.
+// It returns the number of revoked consents.
+func (g *GoCloak) RevokeClientsConsents(ctx context.Context, accessToken, realm string, clientIDs []string) (int, error) {
+	const pageSize = 100
+
+	clients := make(map[string]struct{}, len(clientIDs))
+	for _, clientID := range clientIDs {
+		clients[clientID] = struct{}{}
+	}
+
+	revoked := 0
+	for first := 0; ; first += pageSize {
+		users, err := g.GetUsers(ctx, accessToken, realm, GetUsersParams{
+			BriefRepresentation: BoolP(true),
+			First:               IntP(first),
+			Max:                 IntP(pageSize),
+		})
+		if err != nil {
+			return revoked, err
+		}
+
+		for _, user := range users {
+			consents, err := g.GetUserConsents(ctx, accessToken, realm, PString(user.ID))
+			if err != nil {
+				return revoked, err
+			}
+
+			for _, consent := range consents {
+				if _, ok := clients[PString(consent.ClientID)]; !ok {
+					continue
+				}
+				if err := g.RevokeUserConsents(ctx, accessToken, realm, PString(user.ID), PString(consent.ClientID)); err != nil {
+					return revoked, err
+				}
+				revoked++
+			}
+		}
+
+		if len(users) < pageSize {
+			return revoked, nil
+		}
+	}
+}
+
 // RevokeUserConsents revokes the given user consent.
 func (g *GoCloak) RevokeUserConsents(ctx context.Context, accessToken, realm, userID, clientID string) error {
 	const errMessage = "could not revoke consents"
@@ -1026,6 +1138,78 @@ func (g *GoCloak) UpdateClientManagementPermissions(ctx context.Context, accessT
 	return &result, nil
 }
 
+// UpdateRealmRoleManagementPermissions updates the management permissions of the realm role with the given name
+func (g *GoCloak) UpdateRealmRoleManagementPermissions(ctx context.Context, accessToken, realm string, roleName string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not update realm role management permissions"
+
+	var result ManagementPermissionRepresentation
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		SetBody(managementPermissions).
+		Put(g.getAdminRealmURL(realm, "roles", roleName, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateRoleByIDManagementPermissions updates the management permissions of the role with the given id
+func (g *GoCloak) UpdateRoleByIDManagementPermissions(ctx context.Context, accessToken, realm string, roleID string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not update role management permissions"
+
+	var result ManagementPermissionRepresentation
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		SetBody(managementPermissions).
+		Put(g.getAdminRealmURL(realm, "roles-by-id", roleID, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateClientRoleManagementPermissions updates the management permissions of the client role with the given name
+func (g *GoCloak) UpdateClientRoleManagementPermissions(ctx context.Context, accessToken, realm string, idOfClient, roleName string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not update client role management permissions"
+
+	var result ManagementPermissionRepresentation
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		SetBody(managementPermissions).
+		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "roles", roleName, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateIdentityProviderManagementPermissions updates the management permissions of the identity provider with the given alias
+func (g *GoCloak) UpdateIdentityProviderManagementPermissions(ctx context.Context, accessToken, realm string, alias string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not update identity provider management permissions"
+
+	var result ManagementPermissionRepresentation
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		SetBody(managementPermissions).
+		Put(g.getAdminRealmURL(realm, "identity-provider", "instances", alias, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // UpdateRole updates the given role.
 func (g *GoCloak) UpdateRole(ctx context.Context, token, realm, idOfClient string, role Role) error {
 	const errMessage = "could not update role"
@@ -1550,141 +1734,213 @@ func (g *GoCloak) RegenerateClientSecret(ctx context.Context, token, realm, idOf
 	return &result, nil
 }
 
-// GetClientOfflineSessions returns offline sessions associated with the client
-func (g *GoCloak) GetClientOfflineSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
-	const errMessage = "could not get client offline sessions"
-	var res []*UserSessionRepresentation
-
-	queryParams := map[string]string{}
-	if len(params) > 0 {
-		var err error
-
-		queryParams, err = GetQueryParams(params[0])
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", errMessage, err)
-		}
-	}
+// GetClientRotatedSecret returns the rotated client secret which stays valid until it expires or is invalidated.
+// Keycloak only keeps a rotated secret if a client policy with the secret-rotation executor applies to the client.
+func (g *GoCloak) GetClientRotatedSecret(ctx context.Context, token, realm, idOfClient string) (*CredentialRepresentation, error) {
+	const errMessage = "could not get rotated client secret"
 
+	var result CredentialRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetResult(&res).
-		SetQueryParams(queryParams).
-		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "offline-sessions"))
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "client-secret", "rotated"))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return &result, nil
 }
 
-// GetClientUserSessions returns user sessions associated with the client
-func (g *GoCloak) GetClientUserSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
-	const errMessage = "could not get client user sessions"
-	var res []*UserSessionRepresentation
+// InvalidateClientRotatedSecret invalidates the rotated client secret, so only the current secret remains valid
+func (g *GoCloak) InvalidateClientRotatedSecret(ctx context.Context, token, realm, idOfClient string) error {
+	const errMessage = "could not invalidate rotated client secret"
 
-	queryParams := map[string]string{}
-	if len(params) > 0 {
-		var err error
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "client-secret", "rotated"))
 
-		queryParams, err = GetQueryParams(params[0])
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", errMessage, err)
+	return checkForError(resp, err, errMessage)
+}
+
+// GetClientSecretRotationInfo returns the current and the rotated client secret together with their creation and expiration times.
+// RotatedSecret is nil if the client has no valid rotated secret.
+func (g *GoCloak) GetClientSecretRotationInfo(ctx context.Context, token, realm, idOfClient string) (*ClientSecretRotationInfo, error) {
+	client, err := g.GetClient(ctx, token, realm, idOfClient)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := g.GetClientSecret(ctx, token, realm, idOfClient)
+	if err != nil {
+		return nil, err
+	}
+
+	result := ClientSecretRotationInfo{
+		Secret:               secret,
+		SecretCreationTime:   getInt64Attribute(client.Attributes, "client.secret.creation.time"),
+		SecretExpirationTime: getInt64Attribute(client.Attributes, "client.secret.expiration.time"),
+	}
+
+	rotated, err := g.GetClientRotatedSecret(ctx, token, realm, idOfClient)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			return &result, nil
 		}
+		return nil, err
 	}
 
-	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetResult(&res).
-		SetQueryParams(queryParams).
-		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "user-sessions"))
+	result.RotatedSecret = rotated
+	result.RotatedSecretCreationTime = getInt64Attribute(client.Attributes, "client.secret.rotated.creation.time")
+	result.RotatedSecretExpirationTime = getInt64Attribute(client.Attributes, "client.secret.rotated.expiration.time")
 
-	if err = checkForError(resp, err, errMessage); err != nil {
+	return &result, nil
+}
+
+// RotateClientSecret regenerates the client secret and returns the new rotation state.
+// If secret rotation is enabled for the client, the previous secret stays valid as rotated secret
+// until it expires or InvalidateClientRotatedSecret is called, which allows a zero-downtime rotation.
+func (g *GoCloak) RotateClientSecret(ctx context.Context, token, realm, idOfClient string) (*ClientSecretRotationInfo, error) {
+	if _, err := g.RegenerateClientSecret(ctx, token, realm, idOfClient); err != nil {
 		return nil, err
 	}
 
-	return res, nil
+	return g.GetClientSecretRotationInfo(ctx, token, realm, idOfClient)
 }
 
-// CreateClientProtocolMapper creates a protocol mapper in client scope
-func (g *GoCloak) CreateClientProtocolMapper(ctx context.Context, token, realm, idOfClient string, mapper ProtocolMapperRepresentation) (string, error) {
-	const errMessage = "could not create client protocol mapper"
+// GetClientCertificate returns the certificate info of a client for the given attribute prefix,
+// e.g. "jwt.credential" for clients using private_key_jwt or "saml.signing" for SAML clients.
+func (g *GoCloak) GetClientCertificate(ctx context.Context, token, realm, idOfClient, attr string) (*CertificateRepresentation, error) {
+	const errMessage = "could not get client certificate"
 
+	var result CertificateRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetBody(mapper).
-		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models"))
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "certificates", attr))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return getID(resp), nil
+	return &result, nil
 }
 
-// UpdateClientProtocolMapper updates a protocol mapper in client scope
-func (g *GoCloak) UpdateClientProtocolMapper(ctx context.Context, token, realm, idOfClient, mapperID string, mapper ProtocolMapperRepresentation) error {
-	const errMessage = "could not update client protocol mapper"
+// GenerateClientCertificate generates a new certificate with a new key pair for the client
+func (g *GoCloak) GenerateClientCertificate(ctx context.Context, token, realm, idOfClient, attr string) (*CertificateRepresentation, error) {
+	const errMessage = "could not generate client certificate"
 
+	var result CertificateRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetBody(mapper).
-		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models", mapperID))
+		SetResult(&result).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "certificates", attr, "generate"))
 
-	return checkForError(resp, err, errMessage)
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
-// DeleteClientProtocolMapper deletes a protocol mapper in client scope
-func (g *GoCloak) DeleteClientProtocolMapper(ctx context.Context, token, realm, idOfClient, mapperID string) error {
-	const errMessage = "could not delete client protocol mapper"
+// DownloadClientCertificate returns a keystore file for the client, containing the private key and public certificate
+func (g *GoCloak) DownloadClientCertificate(ctx context.Context, token, realm, idOfClient, attr string, config ClientKeyStoreConfig) ([]byte, error) {
+	const errMessage = "could not download client certificate"
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models", mapperID))
+		SetHeader("Accept", "application/octet-stream").
+		SetBody(config).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "certificates", attr, "download"))
 
-	return checkForError(resp, err, errMessage)
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return resp.Body(), nil
 }
 
-// GetKeyStoreConfig get keystoreconfig of the realm
-func (g *GoCloak) GetKeyStoreConfig(ctx context.Context, token, realm string) (*KeyStoreConfig, error) {
-	const errMessage = "could not get key store config"
+// GenerateAndDownloadClientCertificate generates a new key pair and certificate and returns the private key
+// in the requested keystore format. Only the public certificate is saved in Keycloak.
+func (g *GoCloak) GenerateAndDownloadClientCertificate(ctx context.Context, token, realm, idOfClient, attr string, config ClientKeyStoreConfig) ([]byte, error) {
+	const errMessage = "could not generate and download client certificate"
 
-	var result KeyStoreConfig
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "keys"))
+		SetHeader("Accept", "application/octet-stream").
+		SetBody(config).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "certificates", attr, "generate-and-download"))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	return resp.Body(), nil
 }
 
-// GetComponents get all components in realm
-func (g *GoCloak) GetComponents(ctx context.Context, token, realm string) ([]*Component, error) {
-	const errMessage = "could not get components"
+// UploadClientCertificate uploads a certificate and, depending on the keystore format, its private key
+func (g *GoCloak) UploadClientCertificate(ctx context.Context, token, realm, idOfClient, attr string, params UploadClientCertificateParams, fileName string, fileBody io.Reader) (*CertificateRepresentation, error) {
+	const errMessage = "could not upload client certificate"
 
-	var result []*Component
+	return g.uploadClientCertificate(ctx, token, realm, idOfClient, attr, "upload", params, fileName, fileBody, errMessage)
+}
+
+// UploadClientCertificateOnly uploads only a certificate, not the private key
+func (g *GoCloak) UploadClientCertificateOnly(ctx context.Context, token, realm, idOfClient, attr string, params UploadClientCertificateParams, fileName string, fileBody io.Reader) (*CertificateRepresentation, error) {
+	const errMessage = "could not upload client certificate"
+
+	return g.uploadClientCertificate(ctx, token, realm, idOfClient, attr, "upload-certificate", params, fileName, fileBody, errMessage)
+}
+
+func (g *GoCloak) uploadClientCertificate(ctx context.Context, token, realm, idOfClient, attr, action string, params UploadClientCertificateParams, fileName string, fileBody io.Reader, errMessage string) (*CertificateRepresentation, error) {
+	if params.KeystoreFormat == nil {
+		return nil, errors.New("keystoreFormat is required for uploading a client certificate")
+	}
+
+	var result CertificateRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "components"))
+		SetFileReader("file", fileName, fileBody).
+		SetFormData(params.FormData()).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "certificates", attr, action))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return &result, nil
 }
 
-// GetComponentsWithParams get all components in realm with query params
-func (g *GoCloak) GetComponentsWithParams(ctx context.Context, token, realm string, params GetComponentsParams) ([]*Component, error) {
-	const errMessage = "could not get components"
-	var result []*Component
+// GenerateExampleAccessToken returns the payload of an example access token the given user would receive
+// for the client and the requested scope
+func (g *GoCloak) GenerateExampleAccessToken(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) (jwt.MapClaims, error) {
+	const errMessage = "could not generate example access token"
+
+	return g.generateExample(ctx, token, realm, idOfClient, "generate-example-access-token", params, errMessage)
+}
+
+// GenerateExampleIDToken returns the payload of an example id token the given user would receive
+// for the client and the requested scope
+func (g *GoCloak) GenerateExampleIDToken(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) (jwt.MapClaims, error) {
+	const errMessage = "could not generate example id token"
+
+	return g.generateExample(ctx, token, realm, idOfClient, "generate-example-id-token", params, errMessage)
+}
 
+// GenerateExampleUserInfo returns the example userinfo response the given user would receive
+// for the client and the requested scope. The audience parameter is ignored.
+func (g *GoCloak) GenerateExampleUserInfo(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) (map[string]any, error) {
+	const errMessage = "could not generate example userinfo"
+
+	return g.generateExample(ctx, token, realm, idOfClient, "generate-example-userinfo", params, errMessage)
+}
+
+func (g *GoCloak) generateExample(ctx context.Context, token, realm, idOfClient, example string, params EvaluateScopesParams, errMessage string) (map[string]any, error) {
 	queryParams, err := GetQueryParams(params)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errMessage, err)
 	}
+
+	var result map[string]any
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
 		SetQueryParams(queryParams).
-		Get(g.getAdminRealmURL(realm, "components"))
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "evaluate-scopes", example))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -1693,16 +1949,21 @@ func (g *GoCloak) GetComponentsWithParams(ctx context.Context, token, realm stri
 	return result, nil
 }
 
-// GetComponent get exactly one component by ID
-func (g *GoCloak) GetComponent(ctx context.Context, token, realm string, componentID string) (*Component, error) {
-	const errMessage = "could not get components"
-	var result *Component
+// GetEvaluateScopesProtocolMappers returns all protocol mappers which are used when generating tokens for the client
+// and the requested scope, including the mappers of the client scopes linked with the client
+func (g *GoCloak) GetEvaluateScopesProtocolMappers(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) ([]*ProtocolMapperEvaluationRepresentation, error) {
+	const errMessage = "could not get evaluated protocol mappers"
 
-	componentURL := fmt.Sprintf("components/%s", componentID)
+	queryParams, err := GetQueryParams(EvaluateScopesParams{Scope: params.Scope})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
 
+	var result []*ProtocolMapperEvaluationRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, componentURL))
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "evaluate-scopes", "protocol-mappers"))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -1711,26 +1972,224 @@ func (g *GoCloak) GetComponent(ctx context.Context, token, realm string, compone
 	return result, nil
 }
 
-// UpdateComponent updates the given component
-func (g *GoCloak) UpdateComponent(ctx context.Context, token, realm string, component Component) error {
-	const errMessage = "could not update component"
-
-	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetBody(component).
-		Put(g.getAdminRealmURL(realm, "components", PString(component.ID)))
+// GetEvaluateScopesGrantedScopeMappings returns the effective roles of a role container which the client
+// is allowed to have in its access token for the requested scope.
+// roleContainerID is either the realm name or the id of a client.
+func (g *GoCloak) GetEvaluateScopesGrantedScopeMappings(ctx context.Context, token, realm, idOfClient, roleContainerID string, params EvaluateScopesParams) ([]*Role, error) {
+	const errMessage = "could not get granted scope mappings"
 
-	return checkForError(resp, err, errMessage)
+	return g.getEvaluateScopesScopeMappings(ctx, token, realm, idOfClient, roleContainerID, "granted", params, errMessage)
 }
 
-// GetDefaultGroups returns a list of default groups
-func (g *GoCloak) GetDefaultGroups(ctx context.Context, token, realm string) ([]*Group, error) {
-	const errMessage = "could not get default groups"
+// GetEvaluateScopesNotGrantedScopeMappings returns the roles of a role container which the client
+// can not have in its access token for the requested scope.
+// roleContainerID is either the realm name or the id of a client.
+func (g *GoCloak) GetEvaluateScopesNotGrantedScopeMappings(ctx context.Context, token, realm, idOfClient, roleContainerID string, params EvaluateScopesParams) ([]*Role, error) {
+	const errMessage = "could not get not granted scope mappings"
 
-	var result []*Group
+	return g.getEvaluateScopesScopeMappings(ctx, token, realm, idOfClient, roleContainerID, "not-granted", params, errMessage)
+}
+
+func (g *GoCloak) getEvaluateScopesScopeMappings(ctx context.Context, token, realm, idOfClient, roleContainerID, grant string, params EvaluateScopesParams, errMessage string) ([]*Role, error) {
+	queryParams, err := GetQueryParams(EvaluateScopesParams{Scope: params.Scope})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
 
+	var result []*Role
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "default-groups"))
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "evaluate-scopes", "scope-mappings", roleContainerID, grant))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetClientOfflineSessions returns offline sessions associated with the client
+func (g *GoCloak) GetClientOfflineSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
+	const errMessage = "could not get client offline sessions"
+	var res []*UserSessionRepresentation
+
+	queryParams := map[string]string{}
+	if len(params) > 0 {
+		var err error
+
+		queryParams, err = GetQueryParams(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errMessage, err)
+		}
+	}
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&res).
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "offline-sessions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetClientUserSessions returns user sessions associated with the client
+func (g *GoCloak) GetClientUserSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error) {
+	const errMessage = "could not get client user sessions"
+	var res []*UserSessionRepresentation
+
+	queryParams := map[string]string{}
+	if len(params) > 0 {
+		var err error
+
+		queryParams, err = GetQueryParams(params[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errMessage, err)
+		}
+	}
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&res).
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "user-sessions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateClientProtocolMapper creates a protocol mapper in client scope
+func (g *GoCloak) CreateClientProtocolMapper(ctx context.Context, token, realm, idOfClient string, mapper ProtocolMapperRepresentation) (string, error) {
+	const errMessage = "could not create client protocol mapper"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(mapper).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return "", err
+	}
+
+	return getID(resp), nil
+}
+
+// UpdateClientProtocolMapper updates a protocol mapper in client scope
+func (g *GoCloak) UpdateClientProtocolMapper(ctx context.Context, token, realm, idOfClient, mapperID string, mapper ProtocolMapperRepresentation) error {
+	const errMessage = "could not update client protocol mapper"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(mapper).
+		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models", mapperID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// DeleteClientProtocolMapper deletes a protocol mapper in client scope
+func (g *GoCloak) DeleteClientProtocolMapper(ctx context.Context, token, realm, idOfClient, mapperID string) error {
+	const errMessage = "could not delete client protocol mapper"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "clients", idOfClient, "protocol-mappers", "models", mapperID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// GetKeyStoreConfig get keystoreconfig of the realm
+func (g *GoCloak) GetKeyStoreConfig(ctx context.Context, token, realm string) (*KeyStoreConfig, error) {
+	const errMessage = "could not get key store config"
+
+	var result KeyStoreConfig
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "keys"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetComponents get all components in realm
+func (g *GoCloak) GetComponents(ctx context.Context, token, realm string) ([]*Component, error) {
+	const errMessage = "could not get components"
+
+	var result []*Component
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "components"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetComponentsWithParams get all components in realm with query params
+func (g *GoCloak) GetComponentsWithParams(ctx context.Context, token, realm string, params GetComponentsParams) ([]*Component, error) {
+	const errMessage = "could not get components"
+	var result []*Component
+
+	queryParams, err := GetQueryParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "components"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetComponent get exactly one component by ID
+func (g *GoCloak) GetComponent(ctx context.Context, token, realm string, componentID string) (*Component, error) {
+	const errMessage = "could not get components"
+	var result *Component
+
+	componentURL := fmt.Sprintf("components/%s", componentID)
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, componentURL))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateComponent updates the given component
+func (g *GoCloak) UpdateComponent(ctx context.Context, token, realm string, component Component) error {
+	const errMessage = "could not update component"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(component).
+		Put(g.getAdminRealmURL(realm, "components", PString(component.ID)))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// GetDefaultGroups returns a list of default groups
+func (g *GoCloak) GetDefaultGroups(ctx context.Context, token, realm string) ([]*Group, error) {
+	const errMessage = "could not get default groups"
+
+	var result []*Group
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "default-groups"))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -2158,6 +2617,70 @@ func (g *GoCloak) GetClientManagementPermissions(ctx context.Context, token, rea
 	return &result, nil
 }
 
+// GetRealmRoleManagementPermissions returns the management permissions of the realm role with the given name
+func (g *GoCloak) GetRealmRoleManagementPermissions(ctx context.Context, token, realm string, roleName string) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not get realm role management permissions"
+
+	var result ManagementPermissionRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "roles", roleName, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetRoleByIDManagementPermissions returns the management permissions of the role with the given id
+func (g *GoCloak) GetRoleByIDManagementPermissions(ctx context.Context, token, realm string, roleID string) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not get role management permissions"
+
+	var result ManagementPermissionRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "roles-by-id", roleID, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetClientRoleManagementPermissions returns the management permissions of the client role with the given name
+func (g *GoCloak) GetClientRoleManagementPermissions(ctx context.Context, token, realm string, idOfClient, roleName string) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not get client role management permissions"
+
+	var result ManagementPermissionRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "roles", roleName, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetIdentityProviderManagementPermissions returns the management permissions of the identity provider with the given alias
+func (g *GoCloak) GetIdentityProviderManagementPermissions(ctx context.Context, token, realm string, alias string) (*ManagementPermissionRepresentation, error) {
+	const errMessage = "could not get identity provider management permissions"
+
+	var result ManagementPermissionRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "identity-provider", "instances", alias, "management", "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // UserAttributeContains checks if the given attribute value is set
 func UserAttributeContains(attributes map[string][]string, attribute, value string) bool {
 	for _, item := range attributes[attribute] {
@@ -2587,37 +3110,171 @@ func (g *GoCloak) ClearKeysCache(ctx context.Context, token, realm string) error
 	return checkForError(resp, err, errMessage)
 }
 
-// GetAuthenticationFlows get all authentication flows from a realm
-func (g *GoCloak) GetAuthenticationFlows(ctx context.Context, token, realm string) ([]*AuthenticationFlowRepresentation, error) {
-	const errMessage = "could not retrieve authentication flows"
-	var result []*AuthenticationFlowRepresentation
+// GetRealmLocalizationLocales returns the locales which have localization texts in the realm
+func (g *GoCloak) GetRealmLocalizationLocales(ctx context.Context, token, realm string) ([]string, error) {
+	const errMessage = "could not get realm localization locales"
+
+	var result []string
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "authentication", "flows"))
+		Get(g.getAdminRealmURL(realm, "localization"))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
+
 	return result, nil
 }
 
-// GetAuthenticationFlow get an authentication flow with the given ID
-func (g *GoCloak) GetAuthenticationFlow(ctx context.Context, token, realm string, authenticationFlowID string) (*AuthenticationFlowRepresentation, error) {
-	const errMessage = "could not retrieve authentication flows"
-	var result *AuthenticationFlowRepresentation
+// GetRealmLocalizationTexts returns all localization texts of the realm for the given locale
+func (g *GoCloak) GetRealmLocalizationTexts(ctx context.Context, token, realm, locale string) (map[string]string, error) {
+	const errMessage = "could not get realm localization texts"
+
+	result := make(map[string]string)
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "authentication", "flows", authenticationFlowID))
+		Get(g.getAdminRealmURL(realm, "localization", locale))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
+
 	return result, nil
 }
 
-// CreateAuthenticationFlow creates a new Authentication flow in a realm
-func (g *GoCloak) CreateAuthenticationFlow(ctx context.Context, token, realm string, flow AuthenticationFlowRepresentation) error {
-	const errMessage = "could not create authentication flows"
+// ImportRealmLocalizationTexts creates or overwrites the given localization texts of the realm for the given locale
+func (g *GoCloak) ImportRealmLocalizationTexts(ctx context.Context, token, realm, locale string, texts map[string]string) error {
+	const errMessage = "could not import realm localization texts"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(texts).
+		Post(g.getAdminRealmURL(realm, "localization", locale))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// DeleteRealmLocalizationTexts deletes all localization texts of the realm for the given locale
+func (g *GoCloak) DeleteRealmLocalizationTexts(ctx context.Context, token, realm, locale string) error {
+	const errMessage = "could not delete realm localization texts"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "localization", locale))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// GetRealmLocalizationText returns the localization text of the realm for the given locale and key
+func (g *GoCloak) GetRealmLocalizationText(ctx context.Context, token, realm, locale, key string) (string, error) {
+	const errMessage = "could not get realm localization text"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetHeader("Accept", "text/plain").
+		Get(g.getAdminRealmURL(realm, "localization", locale, key))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return "", err
+	}
+
+	return resp.String(), nil
+}
+
+// UpdateRealmLocalizationText creates or updates the localization text of the realm for the given locale and key
+func (g *GoCloak) UpdateRealmLocalizationText(ctx context.Context, token, realm, locale, key, text string) error {
+	const errMessage = "could not update realm localization text"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetHeader("Content-Type", "text/plain").
+		SetBody(text).
+		Put(g.getAdminRealmURL(realm, "localization", locale, key))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// DeleteRealmLocalizationText deletes the localization text of the realm for the given locale and key
+func (g *GoCloak) DeleteRealmLocalizationText(ctx context.Context, token, realm, locale, key string) error {
+	const errMessage = "could not delete realm localization text"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "localization", locale, key))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// SyncRealmLocalizationTexts makes the localization texts of the realm match the given locale -> key -> text map.
+// Only the differences are applied: changed and missing texts are updated and texts which are not part
+// of the desired locale are deleted. Locales which are not part of the map are left untouched.
+func (g *GoCloak) SyncRealmLocalizationTexts(ctx context.Context, token, realm string, texts map[string]map[string]string) error {
+	for locale, desired := range texts {
+		current, err := g.GetRealmLocalizationTexts(ctx, token, realm, locale)
+		if err != nil {
+			return err
+		}
+
+		updated, deleted := diffLocalizationTexts(current, desired)
+		for _, key := range updated {
+			if err := g.UpdateRealmLocalizationText(ctx, token, realm, locale, key, desired[key]); err != nil {
+				return err
+			}
+		}
+		for _, key := range deleted {
+			if err := g.DeleteRealmLocalizationText(ctx, token, realm, locale, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffLocalizationTexts returns the sorted keys which have to be updated and deleted to turn current into desired
+func diffLocalizationTexts(current, desired map[string]string) (updated, deleted []string) {
+	for key, text := range desired {
+		if currentText, ok := current[key]; !ok || currentText != text {
+			updated = append(updated, key)
+		}
+	}
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			deleted = append(deleted, key)
+		}
+	}
+	sort.Strings(updated)
+	sort.Strings(deleted)
+
+	return updated, deleted
+}
+
+// GetAuthenticationFlows get all authentication flows from a realm
+func (g *GoCloak) GetAuthenticationFlows(ctx context.Context, token, realm string) ([]*AuthenticationFlowRepresentation, error) {
+	const errMessage = "could not retrieve authentication flows"
+	var result []*AuthenticationFlowRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "authentication", "flows"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAuthenticationFlow get an authentication flow with the given ID
+func (g *GoCloak) GetAuthenticationFlow(ctx context.Context, token, realm string, authenticationFlowID string) (*AuthenticationFlowRepresentation, error) {
+	const errMessage = "could not retrieve authentication flows"
+	var result *AuthenticationFlowRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "authentication", "flows", authenticationFlowID))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateAuthenticationFlow creates a new Authentication flow in a realm
+func (g *GoCloak) CreateAuthenticationFlow(ctx context.Context, token, realm string, flow AuthenticationFlowRepresentation) error {
+	const errMessage = "could not create authentication flows"
 	var result []*AuthenticationFlowRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).SetBody(flow).
@@ -2649,6 +3306,16 @@ func (g *GoCloak) DeleteAuthenticationFlow(ctx context.Context, token, realm, fl
 	return checkForError(resp, err, errMessage)
 }
 
+// CopyAuthenticationFlow copies the authentication flow with the given alias, including its executions, under a new alias
+func (g *GoCloak) CopyAuthenticationFlow(ctx context.Context, token, realm, flowAlias, newName string) error {
+	const errMessage = "could not copy authentication flow"
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(map[string]string{"newName": newName}).
+		Post(g.getAdminRealmURL(realm, "authentication", "flows", flowAlias, "copy"))
+
+	return checkForError(resp, err, errMessage)
+}
+
 // CreateAuthenticatorConfig creates a new authenticator configuration and returns its ID
 func (g *GoCloak) CreateAuthenticatorConfig(ctx context.Context, token, realm string, config AuthenticatorConfigRepresentation) (string, error) {
 	const errMessage = "could not create authenticator config"
@@ -2783,6 +3450,174 @@ func (g *GoCloak) CreateAuthenticationExecutionFlow(ctx context.Context, token,
 	return checkForError(resp, err, errMessage)
 }
 
+// RaiseAuthenticationExecutionPriority moves an execution one position up within its flow
+func (g *GoCloak) RaiseAuthenticationExecutionPriority(ctx context.Context, token, realm, executionID string) error {
+	const errMessage = "could not raise authentication execution priority"
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Post(g.getAdminRealmURL(realm, "authentication", "executions", executionID, "raise-priority"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// LowerAuthenticationExecutionPriority moves an execution one position down within its flow
+func (g *GoCloak) LowerAuthenticationExecutionPriority(ctx context.Context, token, realm, executionID string) error {
+	const errMessage = "could not lower authentication execution priority"
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Post(g.getAdminRealmURL(realm, "authentication", "executions", executionID, "lower-priority"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// OrderAuthenticationExecutions raises and lowers the priority of the executions of a flow until their order matches
+// the given execution ids. The ids must list every execution of one level of the flow exactly once, i.e. all top
+// level executions of the flow, or all executions of one of its sub-flows.
+// The executions are read back afterwards and an error is returned if the server order still differs.
+func (g *GoCloak) OrderAuthenticationExecutions(ctx context.Context, token, realm, flowAlias string, executionIDs []string) error {
+	const errMessage = "could not order authentication executions"
+
+	executions, err := g.GetAuthenticationExecutions(ctx, token, realm, flowAlias)
+	if err != nil {
+		return err
+	}
+
+	current, err := authenticationExecutionSiblings(executions, executionIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errMessage, err)
+	}
+
+	err = reorderByPriority(current, executionIDs,
+		func(executionID string) error {
+			return g.RaiseAuthenticationExecutionPriority(ctx, token, realm, executionID)
+		},
+		func(executionID string) error {
+			return g.LowerAuthenticationExecutionPriority(ctx, token, realm, executionID)
+		})
+	if err != nil {
+		return err
+	}
+
+	executions, err = g.GetAuthenticationExecutions(ctx, token, realm, flowAlias)
+	if err != nil {
+		return err
+	}
+
+	current, err = authenticationExecutionSiblings(executions, executionIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errMessage, err)
+	}
+	if !slices.Equal(current, executionIDs) {
+		return fmt.Errorf("%s: executions are ordered %v instead of %v", errMessage, current, executionIDs)
+	}
+
+	return nil
+}
+
+// reorderByPriority raises and lowers the priority of the entries of current until they are in the given order,
+// current is updated along the way. Every call swaps two neighbours, the first or last misplaced entry is moved,
+// whichever is further from its position, so a single misplaced entry is moved directly instead of all others.
+func reorderByPriority(current, order []string, raise, lower func(id string) error) error {
+	for first, last := 0, len(order)-1; first < last; {
+		raiseBy := slices.Index(current, order[first]) - first
+		lowerBy := last - slices.Index(current, order[last])
+		switch {
+		case raiseBy == 0:
+			first++
+		case lowerBy == 0:
+			last--
+		case raiseBy >= lowerBy:
+			for position := first + raiseBy; position > first; position-- {
+				if err := raise(order[first]); err != nil {
+					return err
+				}
+				current[position-1], current[position] = current[position], current[position-1]
+			}
+		default:
+			for position := last - lowerBy; position < last; position++ {
+				if err := lower(order[last]); err != nil {
+					return err
+				}
+				current[position], current[position+1] = current[position+1], current[position]
+			}
+		}
+	}
+
+	return nil
+}
+
+// authenticationExecutionSiblings returns the ids of the executions sharing the parent of the first of executionIDs in
+// their current order. It fails unless executionIDs lists each of them exactly once.
+func authenticationExecutionSiblings(executions []*ModifyAuthenticationExecutionRepresentation, executionIDs []string) ([]string, error) {
+	if len(executionIDs) == 0 {
+		return nil, errors.New("executionIDs required")
+	}
+
+	// executions are returned depth first, the parent of an execution is the last execution one level above it
+	parents := make(map[string]string, len(executions))
+	var path []string
+	for _, execution := range executions {
+		level := PInt(execution.Level)
+		if level > len(path) {
+			return nil, fmt.Errorf("unexpected level of execution %s", PString(execution.ID))
+		}
+		path = path[:level]
+		if level > 0 {
+			parents[PString(execution.ID)] = path[level-1]
+		}
+		path = append(path, PString(execution.ID))
+	}
+
+	var siblings []string
+	parent := parents[executionIDs[0]]
+	for _, execution := range executions {
+		if parents[PString(execution.ID)] == parent && (parent != "" || PInt(execution.Level) == 0) {
+			siblings = append(siblings, PString(execution.ID))
+		}
+	}
+
+	if len(siblings) != len(executionIDs) {
+		return nil, fmt.Errorf("expected %d execution ids of the same level, got %d", len(siblings), len(executionIDs))
+	}
+	for _, executionID := range executionIDs {
+		if !slices.Contains(siblings, executionID) {
+			return nil, fmt.Errorf("execution %s is not part of the same level", executionID)
+		}
+	}
+
+	return siblings, nil
+}
+
+// GetAuthenticatorProviders returns the authenticator providers available for executions
+func (g *GoCloak) GetAuthenticatorProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error) {
+	return g.getAuthenticatorProviders(ctx, token, realm, "authenticator-providers", "could not retrieve authenticator providers")
+}
+
+// GetFormProviders returns the form providers available for form flows
+func (g *GoCloak) GetFormProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error) {
+	return g.getAuthenticatorProviders(ctx, token, realm, "form-providers", "could not retrieve form providers")
+}
+
+// GetFormActionProviders returns the form action providers available for executions of form flows
+func (g *GoCloak) GetFormActionProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error) {
+	return g.getAuthenticatorProviders(ctx, token, realm, "form-action-providers", "could not retrieve form action providers")
+}
+
+// GetClientAuthenticatorProviders returns the client authenticator providers available for client flows
+func (g *GoCloak) GetClientAuthenticatorProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error) {
+	return g.getAuthenticatorProviders(ctx, token, realm, "client-authenticator-providers", "could not retrieve client authenticator providers")
+}
+
+func (g *GoCloak) getAuthenticatorProviders(ctx context.Context, token, realm, providers, errMessage string) ([]*AuthenticatorProviderRepresentation, error) {
+	var result []*AuthenticatorProviderRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "authentication", providers))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // -----
 // Users
 // -----
@@ -2834,6 +3669,44 @@ func (g *GoCloak) GetUserByID(ctx context.Context, accessToken, realm, userID st
 	return &result, nil
 }
 
+// GetUserByIDWithProfileMetadata fetches a user from the given realm with the given userID
+// together with the metadata of its user profile
+func (g *GoCloak) GetUserByIDWithProfileMetadata(ctx context.Context, accessToken, realm, userID string) (*User, error) {
+	const errMessage = "could not get user by id"
+
+	if userID == "" {
+		return nil, fmt.Errorf("%s: userID shall not be empty", errMessage)
+	}
+
+	var result User
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		SetQueryParam("userProfileMetadata", "true").
+		Get(g.getAdminRealmURL(realm, "users", userID))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetUserUnmanagedAttributes returns the attributes of a user which are not managed by the user profile
+func (g *GoCloak) GetUserUnmanagedAttributes(ctx context.Context, accessToken, realm, userID string) (map[string][]string, error) {
+	const errMessage = "could not get unmanaged attributes of user"
+
+	var result map[string][]string
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "users", userID, "unmanagedAttributes"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GetUserCount gets the user count in the realm
 func (g *GoCloak) GetUserCount(ctx context.Context, token string, realm string, params GetUsersParams) (int, error) {
 	const errMessage = "could not get user count"
@@ -2894,6 +3767,23 @@ func (g *GoCloak) GetUserProfileConfig(ctx context.Context, token, realm string)
 	return &result, nil
 }
 
+// GetUserProfileMetadata retrieves the metadata of the user profile for a realm, i.e. the managed attributes
+// and their validators as seen by the admin
+func (g *GoCloak) GetUserProfileMetadata(ctx context.Context, token, realm string) (*UserProfileMetadata, error) {
+	const errMessage = "could not get user profile metadata"
+
+	var result UserProfileMetadata
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "users", "profile", "metadata"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // GetUsers get all users in realm
 // Default number of results per page is 100, use GetUsersParams to specify it explicitly or to set offset for pagination
 func (g *GoCloak) GetUsers(ctx context.Context, token, realm string, params GetUsersParams) ([]*User, error) {
@@ -3187,6 +4077,80 @@ func (g *GoCloak) GetUserBruteForceDetectionStatus(ctx context.Context, accessTo
 	return &result, nil
 }
 
+// ClearUserLoginFailures clears the login failures of a user, which unlocks a user locked by the brute force detection
+func (g *GoCloak) ClearUserLoginFailures(ctx context.Context, accessToken, realm, userID string) error {
+	const errMessage = "could not clear login failures of user"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		Delete(g.getAttackDetectionURL(realm, "users", userID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// ClearAllLoginFailures clears the login failures of all users of the realm, which unlocks all users
+// locked by the brute force detection
+func (g *GoCloak) ClearAllLoginFailures(ctx context.Context, accessToken, realm string) error {
+	const errMessage = "could not clear login failures"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, accessToken).
+		Delete(g.getAttackDetectionURL(realm, "users"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// GetLockedUsers returns the users matching params which are locked by the brute force detection.
+// Keycloak has no endpoint listing locked users, so the brute force status of every matching user is fetched,
+// with up to 10 concurrent requests. Use params to narrow the users down, e.g. with Search.
+// All pages starting at params.First are walked, params.Max sets the page size.
+func (g *GoCloak) GetLockedUsers(ctx context.Context, accessToken, realm string, params GetUsersParams) ([]*LockedUser, error) {
+	const concurrency = 10
+
+	pageSize := 100
+	if params.Max != nil {
+		pageSize = *params.Max
+	}
+	first := PInt(params.First)
+
+	var result []*LockedUser
+	for {
+		params.First = IntP(first)
+		params.Max = IntP(pageSize)
+		users, err := g.GetUsers(ctx, accessToken, realm, params)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses := make([]*BruteForceStatus, len(users))
+		errs := make([]error, len(users))
+		semaphore := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, user := range users {
+			wg.Add(1)
+			semaphore <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				statuses[i], errs[i] = g.GetUserBruteForceDetectionStatus(ctx, accessToken, realm, PString(user.ID))
+			}()
+		}
+		wg.Wait()
+
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		for i, user := range users {
+			if PBool(statuses[i].Disabled) {
+				result = append(result, &LockedUser{User: user, BruteForceStatus: statuses[i]})
+			}
+		}
+
+		if len(users) < pageSize || pageSize <= 0 {
+			return result, nil
+		}
+		first += pageSize
+	}
+}
+
 // ------------------
 // Identity Providers
 // ------------------
@@ -3458,7 +4422,8 @@ func (g *GoCloak) GetResources(ctx context.Context, token, realm, idOfClient str
 	return result, nil
 }
 
-// GetResourcesClient returns resources associated with the client, using access token from client
+// GetResourcesClient returns resources associated with the client, using access token from client.
+// If params.Deep is set, the resources are returned by a single request instead of one request per resource.
 func (g *GoCloak) GetResourcesClient(ctx context.Context, token, realm string, params GetResourceParams) ([]*ResourceRepresentation, error) {
 	const errMessage = "could not get resources"
 
@@ -3468,6 +4433,19 @@ func (g *GoCloak) GetResourcesClient(ctx context.Context, token, realm string, p
 	}
 
 	var result []*ResourceRepresentation
+	if PBool(params.Deep) {
+		resp, err := g.GetRequestWithBearerAuth(ctx, token).
+			SetResult(&result).
+			SetQueryParams(queryParams).
+			Get(g.getRealmURL(realm, "authz", "protection", "resource_set"))
+
+		if err = checkForError(resp, err, errMessage); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
 	var resourceIDs []string
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&resourceIDs).
@@ -3489,6 +4467,8 @@ func (g *GoCloak) GetResourcesClient(ctx context.Context, token, realm string, p
 }
 
 // GetResourceServer returns resource server settings.
+// The result is the export of the resource server: it includes the resources, scopes, policies and permissions,
+// which reference each other by name, and can be imported into another client with ImportResourceServer.
 // The access token must have the realm view_clients role on its service
 // account to be allowed to call this endpoint.
 func (g *GoCloak) GetResourceServer(ctx context.Context, token, realm, idOfClient string) (*ResourceServerRepresentation, error) {
@@ -3506,6 +4486,32 @@ func (g *GoCloak) GetResourceServer(ctx context.Context, token, realm, idOfClien
 	return result, nil
 }
 
+// UpdateResourceServer updates the settings of a client's resource server, i.e. the policy enforcement mode,
+// the decision strategy and whether remote resource management is allowed.
+// Resources, scopes and policies in the representation are ignored, use ImportResourceServer for them.
+func (g *GoCloak) UpdateResourceServer(ctx context.Context, token, realm, idOfClient string, resourceServer ResourceServerRepresentation) error {
+	const errMessage = "could not update resource server settings"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(resourceServer).
+		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// ImportResourceServer imports the settings, resources, scopes, policies and permissions of a resource server,
+// typically exported from another environment with GetResourceServer. References between them are resolved by name,
+// and existing resources, scopes and policies with the same name are updated.
+func (g *GoCloak) ImportResourceServer(ctx context.Context, token, realm, idOfClient string, resourceServer ResourceServerRepresentation) error {
+	const errMessage = "could not import resource server"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(resourceServer).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "import"))
+
+	return checkForError(resp, err, errMessage)
+}
+
 // UpdateResource updates a resource associated with the client, using access token from admin
 func (g *GoCloak) UpdateResource(ctx context.Context, token, realm, idOfClient string, resource ResourceRepresentation) error {
 	const errMessage = "could not update resource"
@@ -3800,14 +4806,15 @@ func (g *GoCloak) DeletePolicy(ctx context.Context, token, realm, idOfClient, po
 	return checkForError(resp, err, errMessage)
 }
 
-// GetAuthorizationPolicyAssociatedPolicies returns a client's associated policies of specific policy with the given policy id, using access token from admin
-func (g *GoCloak) GetAuthorizationPolicyAssociatedPolicies(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyRepresentation, error) {
-	const errMessage = "could not get policy associated policies"
+// GetTypedPolicy returns a policy of the given type associated with the client, e.g. a *RolePolicy for type "role".
+// Policies of types without a typed representation are returned as *GenericPolicy.
+func (g *GoCloak) GetTypedPolicy(ctx context.Context, token, realm, idOfClient, policyType, policyID string) (TypedPolicy, error) {
+	const errMessage = "could not get policy"
 
-	var result []*PolicyRepresentation
+	result := newTypedPolicy(policyType)
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyID, "associatedPolicies"))
+		SetResult(result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyType, policyID))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
@@ -3816,11 +4823,114 @@ func (g *GoCloak) GetAuthorizationPolicyAssociatedPolicies(ctx context.Context,
 	return result, nil
 }
 
-// GetAuthorizationPolicyResources returns a client's resources of specific policy with the given policy id, using access token from admin
-func (g *GoCloak) GetAuthorizationPolicyResources(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyResourceRepresentation, error) {
-	const errMessage = "could not get policy resources"
-
-	var result []*PolicyResourceRepresentation
+// GetTypedPolicies returns the policies associated with the client decoded into their typed representations,
+// see DecodePolicyRepresentation. Set params.Permission to false to leave out the permissions.
+func (g *GoCloak) GetTypedPolicies(ctx context.Context, token, realm, idOfClient string, params GetPolicyParams) ([]TypedPolicy, error) {
+	policies, err := g.GetPolicies(ctx, token, realm, idOfClient, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TypedPolicy, 0, len(policies))
+	for _, policy := range policies {
+		typed, err := DecodePolicyRepresentation(policy)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, typed)
+	}
+
+	return result, nil
+}
+
+// CreateTypedPolicy creates a policy associated with the client, the type is taken from the given policy
+func (g *GoCloak) CreateTypedPolicy(ctx context.Context, token, realm, idOfClient string, policy TypedPolicy) (TypedPolicy, error) {
+	const errMessage = "could not create policy"
+
+	policyType := policy.PolicyType()
+	if policyType == "" {
+		return nil, errors.New("type of a policy required")
+	}
+	policy.Base().Type = &policyType
+
+	result := newTypedPolicy(policyType)
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(result).
+		SetBody(policy).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyType))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateTypedPolicy updates a policy associated with the client, the type is taken from the given policy
+func (g *GoCloak) UpdateTypedPolicy(ctx context.Context, token, realm, idOfClient string, policy TypedPolicy) error {
+	const errMessage = "could not update policy"
+
+	if NilOrEmpty(policy.Base().ID) {
+		return errors.New("ID of a policy required")
+	}
+
+	policyType := policy.PolicyType()
+	if policyType == "" {
+		return errors.New("type of a policy required")
+	}
+	policy.Base().Type = &policyType
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(policy).
+		Put(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyType, *policy.Base().ID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// EvaluatePolicies evaluates the permissions of a client's resource server for a user, like the "Evaluate" tab
+// of the admin console. The response contains the decision per resource together with the permissions and
+// policies which granted or denied access.
+func (g *GoCloak) EvaluatePolicies(ctx context.Context, token, realm, idOfClient string, request PolicyEvaluationRequest) (*PolicyEvaluationResponse, error) {
+	const errMessage = "could not evaluate policies"
+
+	if NilOrEmpty(request.UserID) && len(request.RoleIDs) == 0 {
+		return nil, errors.New("userID or roleIDs of a policy evaluation required")
+	}
+
+	var result PolicyEvaluationResponse
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetBody(request).
+		Post(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", "evaluate"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAuthorizationPolicyAssociatedPolicies returns a client's associated policies of specific policy with the given policy id, using access token from admin
+func (g *GoCloak) GetAuthorizationPolicyAssociatedPolicies(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyRepresentation, error) {
+	const errMessage = "could not get policy associated policies"
+
+	var result []*PolicyRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyID, "associatedPolicies"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAuthorizationPolicyResources returns a client's resources of specific policy with the given policy id, using access token from admin
+func (g *GoCloak) GetAuthorizationPolicyResources(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyResourceRepresentation, error) {
+	const errMessage = "could not get policy resources"
+
+	var result []*PolicyResourceRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
 		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", policyID, "resources"))
@@ -3987,6 +5097,82 @@ func (g *GoCloak) GetScopePermissions(ctx context.Context, token, realm, idOfCli
 	return result, nil
 }
 
+// GetResourcePermissions returns the permissions protecting the resource with the given id,
+// i.e. the resource based permissions of the resource and the scope based permissions of its scopes
+func (g *GoCloak) GetResourcePermissions(ctx context.Context, token, realm, idOfClient, resourceID string) ([]*PolicyRepresentation, error) {
+	const errMessage = "could not get resource permissions"
+
+	var result []*PolicyRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "resource", resourceID, "permissions"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetResourceAttributes returns the attributes of the resource with the given id
+func (g *GoCloak) GetResourceAttributes(ctx context.Context, token, realm, idOfClient, resourceID string) (map[string][]string, error) {
+	const errMessage = "could not get resource attributes"
+
+	var result map[string][]string
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "resource", resourceID, "attributes"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SearchPermission returns the permission of the client with the given name, or nil if there is none
+func (g *GoCloak) SearchPermission(ctx context.Context, token, realm, idOfClient, name string) (*PermissionRepresentation, error) {
+	const errMessage = "could not search permission"
+
+	var result PermissionRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetQueryParam("name", name).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "permission", "search"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNoContent { // no permission with the name
+		return nil, nil
+	}
+
+	return &result, nil
+}
+
+// SearchPolicy returns the policy of the client with the given name, or nil if there is none.
+// Permissions are found as well, as they are policies of the types "resource" and "scope".
+func (g *GoCloak) SearchPolicy(ctx context.Context, token, realm, idOfClient, name string) (*PolicyRepresentation, error) {
+	const errMessage = "could not search policy"
+
+	var result PolicyRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetQueryParam("name", name).
+		Get(g.getAdminRealmURL(realm, "clients", idOfClient, "authz", "resource-server", "policy", "search"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNoContent { // no policy with the name
+		return nil, nil
+	}
+
+	return &result, nil
+}
+
 // GetPermissionScopes returns a client's scopes configured for the given permission id
 func (g *GoCloak) GetPermissionScopes(ctx context.Context, token, realm, idOfClient, permissionID string) ([]*PermissionScope, error) {
 	const errMessage = "could not get permission scopes"
@@ -4030,6 +5216,75 @@ func (g *GoCloak) GetPermissions(ctx context.Context, token, realm, idOfClient s
 	return result, nil
 }
 
+// GetAdminPermissionsClient returns the admin-permissions client of the realm, which holds the resource server
+// of the fine-grained admin permissions v2. These are available from Keycloak 26.2 and have to be enabled
+// with the adminPermissionsEnabled setting of the realm. They replace the management permissions of
+// e.g. UpdateUsersManagementPermissions, which are only used in realms without them.
+// Pass the ID of the returned client to GetAdminPermissions, CreateAdminPermission and EvaluateAdminPermissions.
+func (g *GoCloak) GetAdminPermissionsClient(ctx context.Context, token, realm string) (*Client, error) {
+	const errMessage = "could not get admin permissions client"
+
+	compResult, err := g.compareVersions(ctx, "26.2.0", token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+	if compResult < 0 {
+		return nil, fmt.Errorf("%s: fine-grained admin permissions v2 require keycloak 26.2.0 or later", errMessage)
+	}
+
+	realmRepresentation, err := g.GetRealm(ctx, token, realm)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+	if !PBool(realmRepresentation.AdminPermissionsEnabled) {
+		return nil, fmt.Errorf("%s: fine-grained admin permissions v2 are not enabled in realm %s", errMessage, realm)
+	}
+	if realmRepresentation.AdminPermissionsClient != nil {
+		return realmRepresentation.AdminPermissionsClient, nil
+	}
+
+	clients, err := g.GetClients(ctx, token, realm, GetClientsParams{ClientID: StringP("admin-permissions")})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("%s: admin-permissions client not found in realm %s", errMessage, realm)
+	}
+
+	return clients[0], nil
+}
+
+// GetAdminPermissions returns the fine-grained admin permissions v2 of the realm, idOfClient is the ID of the client
+// returned by GetAdminPermissionsClient. Use params.ResourceType to get the permissions of a resource type only.
+func (g *GoCloak) GetAdminPermissions(ctx context.Context, token, realm, idOfClient string, params GetPermissionParams) ([]*PermissionRepresentation, error) {
+	return g.GetPermissions(ctx, token, realm, idOfClient, params)
+}
+
+// CreateAdminPermission creates a fine-grained admin permission v2 on the client returned by
+// GetAdminPermissionsClient. The permission grants its scopes, e.g. "view" or "manage", for the resources of
+// its resource type, or for all resources of the type if no resources are given, to everyone matching its
+// policies. Policies are created on the same client. Use UpdatePermission and DeletePermission with the ID of
+// that client to change the permission.
+func (g *GoCloak) CreateAdminPermission(ctx context.Context, token, realm, idOfClient string, permission PermissionRepresentation) (*PermissionRepresentation, error) {
+	if NilOrEmpty(permission.ResourceType) {
+		return nil, errors.New("resourceType of an admin permission required")
+	}
+
+	permission.Type = StringP("scope")
+	return g.CreatePermission(ctx, token, realm, idOfClient, permission)
+}
+
+// EvaluateAdminPermissions evaluates the fine-grained admin permissions v2 of a user or roles for the resources
+// of request.ResourceType, like the "Evaluation" tab of the permissions in the admin console.
+// idOfClient is the ID of the client returned by GetAdminPermissionsClient.
+func (g *GoCloak) EvaluateAdminPermissions(ctx context.Context, token, realm, idOfClient string, request PolicyEvaluationRequest) (*PolicyEvaluationResponse, error) {
+	if NilOrEmpty(request.ResourceType) {
+		return nil, errors.New("resourceType of an admin permission evaluation required")
+	}
+
+	return g.EvaluatePolicies(ctx, token, realm, idOfClient, request)
+}
+
 // checkPermissionTicketParams checks that mandatory fields are present
 func checkPermissionTicketParams(permissions []CreatePermissionTicketParams) error {
 	if len(permissions) == 0 {
@@ -4259,124 +5514,442 @@ func (g *GoCloak) GetConfiguredUserStorageCredentialTypes(ctx context.Context, t
 		SetResult(&result).
 		Get(g.getAdminRealmURL(realm, "users", userID, "configured-user-storage-credential-types"))
 
-	if err = checkForError(resp, err, errMessage); err != nil {
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetCredentials returns credentials available for a given user
+func (g *GoCloak) GetCredentials(ctx context.Context, token, realm, userID string) ([]*CredentialRepresentation, error) {
+	const errMessage = "could not get user credentials"
+
+	var result []*CredentialRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "users", userID, "credentials"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteCredentials deletes the given credential for a given user
+func (g *GoCloak) DeleteCredentials(ctx context.Context, token, realm, userID, credentialID string) error {
+	const errMessage = "could not delete user credentials"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// UpdateCredentialUserLabel updates label for the given credential for the given user
+func (g *GoCloak) UpdateCredentialUserLabel(ctx context.Context, token, realm, userID, credentialID, userLabel string) error {
+	const errMessage = "could not update credential label for a user"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetHeader("Content-Type", "text/plain").
+		SetBody(userLabel).
+		Put(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "userLabel"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// DisableAllCredentialsByType disables all credentials for a user of a specific type
+func (g *GoCloak) DisableAllCredentialsByType(ctx context.Context, token, realm, userID string, types []string) error {
+	const errMessage = "could not update disable credentials"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(types).
+		Put(g.getAdminRealmURL(realm, "users", userID, "disable-credential-types"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// MoveCredentialBehind move a credential to a position behind another credential
+func (g *GoCloak) MoveCredentialBehind(ctx context.Context, token, realm, userID, credentialID, newPreviousCredentialID string) error {
+	const errMessage = "could not move credential"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Post(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "moveAfter", newPreviousCredentialID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// MoveCredentialToFirst move a credential to a first position in the credentials list of the user
+func (g *GoCloak) MoveCredentialToFirst(ctx context.Context, token, realm, userID, credentialID string) error {
+	const errMessage = "could not move credential"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Post(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "moveToFirst"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// GetEvents returns events
+func (g *GoCloak) GetEvents(ctx context.Context, token string, realm string, params GetEventsParams) ([]*EventRepresentation, error) {
+	const errMessage = "could not get events"
+
+	queryParams, err := GetQueryParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+
+	var result []*EventRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "events"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAdminEvents returns admin events
+func (g *GoCloak) GetAdminEvents(ctx context.Context, token string, realm string, params GetAdminEventsParams) ([]*AdminEventRepresentation, error) {
+	const errMessage = "could not get admin events"
+
+	queryParams, err := GetQueryParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMessage, err)
+	}
+
+	var result []*AdminEventRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetQueryParams(queryParams).
+		Get(g.getAdminRealmURL(realm, "admin-events"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// TailEvents polls the events of the realm and sends every new event to the returned channel, oldest first.
+// Keycloak returns events newest first and may insert new events while paging, so every poll collects
+// all pages since the cursor and drops the events which were already sent. Events are identified by their
+// id, or by a hash of their content on servers which do not return ids.
+// Failed polls are reported to options.OnError and retried with exponential backoff.
+// The channel is closed once ctx is done.
+// DateFrom, DateTo, First and Max of params are set by the tail itself, use options.Cursor to resume from a
+// given position. If they are set, or params can not be encoded as query parameters, the error is reported
+// to options.OnError and the returned channel is closed right away.
+func (g *GoCloak) TailEvents(ctx context.Context, token TokenProvider, realm string, params GetEventsParams, options TailEventsOptions) <-chan *TailedEvent {
+	const errMessage = "could not tail events"
+
+	if params.DateFrom != nil || params.DateTo != nil || params.First != nil || params.Max != nil {
+		return failedTail[*TailedEvent](options, fmt.Errorf("%s: dateFrom, dateTo, first and max are set by the tail", errMessage))
+	}
+	if _, err := GetQueryParams(params); err != nil {
+		return failedTail[*TailedEvent](options, fmt.Errorf("%s: %w", errMessage, err))
+	}
+
+	tailer := eventTailer[*EventRepresentation]{
+		options: options,
+		token:   token,
+		fetch: func(ctx context.Context, accessToken string, dateFrom *string, first, max int32) ([]*EventRepresentation, error) {
+			params.DateFrom = dateFrom
+			params.First = &first
+			params.Max = &max
+			return g.GetEvents(ctx, accessToken, realm, params)
+		},
+		timeOf: func(event *EventRepresentation) int64 { return event.Time },
+		hashOf: func(event *EventRepresentation) string { return eventHash(event.ID, event) },
+	}
+
+	result := make(chan *TailedEvent)
+	go func() {
+		defer close(result)
+		tailer.run(ctx, func(event *EventRepresentation, cursor EventCursor) bool {
+			select {
+			case result <- &TailedEvent{Event: event, Cursor: cursor}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	return result
+}
+
+// TailAdminEvents polls the admin events of the realm and sends every new admin event to the returned channel,
+// oldest first. See TailEvents for details.
+func (g *GoCloak) TailAdminEvents(ctx context.Context, token TokenProvider, realm string, params GetAdminEventsParams, options TailEventsOptions) <-chan *TailedAdminEvent {
+	const errMessage = "could not tail admin events"
+
+	if params.DateFrom != nil || params.DateTo != nil || params.First != nil || params.Max != nil {
+		return failedTail[*TailedAdminEvent](options, fmt.Errorf("%s: dateFrom, dateTo, first and max are set by the tail", errMessage))
+	}
+	if _, err := GetQueryParams(params); err != nil {
+		return failedTail[*TailedAdminEvent](options, fmt.Errorf("%s: %w", errMessage, err))
+	}
+
+	tailer := eventTailer[*AdminEventRepresentation]{
+		options: options,
+		token:   token,
+		fetch: func(ctx context.Context, accessToken string, dateFrom *string, first, max int32) ([]*AdminEventRepresentation, error) {
+			params.DateFrom = dateFrom
+			params.First = &first
+			params.Max = &max
+			return g.GetAdminEvents(ctx, accessToken, realm, params)
+		},
+		timeOf: func(event *AdminEventRepresentation) int64 { return event.Time },
+		hashOf: func(event *AdminEventRepresentation) string { return eventHash(event.ID, event) },
+	}
+
+	result := make(chan *TailedAdminEvent)
+	go func() {
+		defer close(result)
+		tailer.run(ctx, func(event *AdminEventRepresentation, cursor EventCursor) bool {
+			select {
+			case result <- &TailedAdminEvent{Event: event, Cursor: cursor}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	return result
+}
+
+// failedTail reports an invalid tail to options.OnError and returns a closed channel
+func failedTail[T any](options TailEventsOptions, err error) <-chan T {
+	if options.OnError != nil {
+		options.OnError(err)
+	}
+
+	result := make(chan T)
+	close(result)
+
+	return result
+}
+
+// eventTailer implements the polling shared by TailEvents and TailAdminEvents
+type eventTailer[T any] struct {
+	options TailEventsOptions
+	token   TokenProvider
+	fetch   func(ctx context.Context, token string, dateFrom *string, first, max int32) ([]T, error)
+	timeOf  func(T) int64
+	hashOf  func(T) string
+}
+
+// run polls until ctx is done or emit returns false
+func (t *eventTailer[T]) run(ctx context.Context, emit func(T, EventCursor) bool) {
+	t.setDefaults()
+
+	var cursor *EventCursor
+	if t.options.Cursor != nil {
+		cursor = &EventCursor{Time: t.options.Cursor.Time, Hashes: append([]string(nil), t.options.Cursor.Hashes...)}
+	}
+
+	var backoff time.Duration
+	for {
+		var err error
+		if cursor == nil {
+			cursor, err = t.latest(ctx)
+		} else {
+			var events []T
+			events, err = t.poll(ctx, *cursor)
+			for _, event := range events {
+				cursor.advance(t.timeOf(event), t.hashOf(event))
+				if !emit(event, EventCursor{Time: cursor.Time, Hashes: append([]string(nil), cursor.Hashes...)}) {
+					return
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		wait := t.options.PollInterval
+		if err != nil {
+			if t.options.OnError != nil {
+				t.options.OnError(err)
+			}
+			backoff = min(max(2*backoff, t.options.MinBackoff), t.options.MaxBackoff)
+			wait = backoff
+		} else {
+			backoff = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *eventTailer[T]) setDefaults() {
+	if t.options.PollInterval <= 0 {
+		t.options.PollInterval = 5 * time.Second
+	}
+	if t.options.PageSize <= 0 {
+		t.options.PageSize = 100
+	}
+	if t.options.MinBackoff <= 0 {
+		t.options.MinBackoff = time.Second
+	}
+	if t.options.MaxBackoff < t.options.MinBackoff {
+		t.options.MaxBackoff = max(time.Minute, t.options.MinBackoff)
+	}
+}
+
+// latest returns a cursor pointing at the most recent event
+func (t *eventTailer[T]) latest(ctx context.Context) (*EventCursor, error) {
+	accessToken, err := t.token(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
-}
+	// without dateFrom Keycloak returns the most recent events first
+	page, err := t.fetch(ctx, accessToken, nil, 0, t.options.PageSize)
+	if err != nil {
+		return nil, err
+	}
 
-// GetCredentials returns credentials available for a given user
-func (g *GoCloak) GetCredentials(ctx context.Context, token, realm, userID string) ([]*CredentialRepresentation, error) {
-	const errMessage = "could not get user credentials"
+	cursor := &EventCursor{}
+	for _, event := range page {
+		cursor.advance(t.timeOf(event), t.hashOf(event))
+	}
 
-	var result []*CredentialRepresentation
-	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetResult(&result).
-		Get(g.getAdminRealmURL(realm, "users", userID, "credentials"))
+	return cursor, nil
+}
 
-	if err = checkForError(resp, err, errMessage); err != nil {
+// poll returns the events which occurred since the cursor, oldest first
+func (t *eventTailer[T]) poll(ctx context.Context, cursor EventCursor) ([]T, error) {
+	accessToken, err := t.token(ctx)
+	if err != nil {
 		return nil, err
 	}
 
-	return result, nil
-}
+	var dateFrom *string
+	if cursor.Time > 0 {
+		dateFrom = StringP(strconv.FormatInt(cursor.Time, 10))
+	}
 
-// DeleteCredentials deletes the given credential for a given user
-func (g *GoCloak) DeleteCredentials(ctx context.Context, token, realm, userID, credentialID string) error {
-	const errMessage = "could not delete user credentials"
+	seen := make(map[string]struct{}, len(cursor.Hashes))
+	for _, hash := range cursor.Hashes {
+		seen[hash] = struct{}{}
+	}
 
-	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		Delete(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID))
+	var result []T
+	for first := int32(0); ; first += t.options.PageSize {
+		page, err := t.fetch(ctx, accessToken, dateFrom, first, t.options.PageSize)
+		if err != nil {
+			return nil, err
+		}
 
-	return checkForError(resp, err, errMessage)
-}
+		// events arriving while paging shift the pages, which only leads to duplicates
+		for _, event := range page {
+			if t.timeOf(event) < cursor.Time {
+				continue
+			}
+			hash := t.hashOf(event)
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+			result = append(result, event)
+		}
 
-// UpdateCredentialUserLabel updates label for the given credential for the given user
-func (g *GoCloak) UpdateCredentialUserLabel(ctx context.Context, token, realm, userID, credentialID, userLabel string) error {
-	const errMessage = "could not update credential label for a user"
+		if int32(len(page)) < t.options.PageSize {
+			break
+		}
+	}
 
-	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetHeader("Content-Type", "text/plain").
-		SetBody(userLabel).
-		Put(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "userLabel"))
+	// pages are ordered newest first, reverse them to keep the order of events within the same millisecond
+	slices.Reverse(result)
+	sort.SliceStable(result, func(i, j int) bool {
+		return t.timeOf(result[i]) < t.timeOf(result[j])
+	})
 
-	return checkForError(resp, err, errMessage)
+	return result, nil
 }
 
-// DisableAllCredentialsByType disables all credentials for a user of a specific type
-func (g *GoCloak) DisableAllCredentialsByType(ctx context.Context, token, realm, userID string, types []string) error {
-	const errMessage = "could not update disable credentials"
+// advance moves the cursor to the given event if it is not older than the cursor
+func (c *EventCursor) advance(time int64, hash string) {
+	switch {
+	case time > c.Time:
+		c.Time = time
+		c.Hashes = []string{hash}
+	case time == c.Time:
+		c.Hashes = append(c.Hashes, hash)
+	}
+}
 
-	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetBody(types).
-		Put(g.getAdminRealmURL(realm, "users", userID, "disable-credential-types"))
+// eventHash returns the id of an event, or a hash of its content if the id is not set
+func eventHash(id *string, event any) string {
+	if !NilOrEmpty(id) {
+		return *id
+	}
 
-	return checkForError(resp, err, errMessage)
+	data, _ := json.Marshal(event)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
 }
 
-// MoveCredentialBehind move a credential to a position behind another credential
-func (g *GoCloak) MoveCredentialBehind(ctx context.Context, token, realm, userID, credentialID, newPreviousCredentialID string) error {
-	const errMessage = "could not move credential"
+// ClearEvents deletes all events of the realm
+func (g *GoCloak) ClearEvents(ctx context.Context, token, realm string) error {
+	const errMessage = "could not clear events"
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		Post(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "moveAfter", newPreviousCredentialID))
+		Delete(g.getAdminRealmURL(realm, "events"))
 
 	return checkForError(resp, err, errMessage)
 }
 
-// MoveCredentialToFirst move a credential to a first position in the credentials list of the user
-func (g *GoCloak) MoveCredentialToFirst(ctx context.Context, token, realm, userID, credentialID string) error {
-	const errMessage = "could not move credential"
+// ClearAdminEvents deletes all admin events of the realm
+func (g *GoCloak) ClearAdminEvents(ctx context.Context, token, realm string) error {
+	const errMessage = "could not clear admin events"
 
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		Post(g.getAdminRealmURL(realm, "users", userID, "credentials", credentialID, "moveToFirst"))
+		Delete(g.getAdminRealmURL(realm, "admin-events"))
 
 	return checkForError(resp, err, errMessage)
 }
 
-// GetEvents returns events
-func (g *GoCloak) GetEvents(ctx context.Context, token string, realm string, params GetEventsParams) ([]*EventRepresentation, error) {
-	const errMessage = "could not get events"
-
-	queryParams, err := GetQueryParams(params)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errMessage, err)
-	}
+// GetEventsConfig returns the events configuration of the realm
+func (g *GoCloak) GetEventsConfig(ctx context.Context, token, realm string) (*RealmEventsConfigRepresentation, error) {
+	const errMessage = "could not get events config"
 
-	var result []*EventRepresentation
+	var result RealmEventsConfigRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
 		SetResult(&result).
-		SetQueryParams(queryParams).
-		Get(g.getAdminRealmURL(realm, "events"))
+		Get(g.getAdminRealmURL(realm, "events", "config"))
 
 	if err = checkForError(resp, err, errMessage); err != nil {
 		return nil, err
 	}
 
-	return result, nil
+	return &result, nil
 }
 
-// GetAdminEvents returns admin events
-func (g *GoCloak) GetAdminEvents(ctx context.Context, token string, realm string, params GetAdminEventsParams) ([]*AdminEventRepresentation, error) {
-	const errMessage = "could not get admin events"
-
-	queryParams, err := GetQueryParams(params)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errMessage, err)
-	}
+// UpdateEventsConfig updates the events configuration of the realm, e.g. the enabled event types,
+// the event listeners and the expiration of stored events
+func (g *GoCloak) UpdateEventsConfig(ctx context.Context, token, realm string, config RealmEventsConfigRepresentation) error {
+	const errMessage = "could not update events config"
 
-	var result []*AdminEventRepresentation
 	resp, err := g.GetRequestWithBearerAuth(ctx, token).
-		SetResult(&result).
-		SetQueryParams(queryParams).
-		Get(g.getAdminRealmURL(realm, "admin-events"))
-
-	if err = checkForError(resp, err, errMessage); err != nil {
-		return nil, err
-	}
+		SetBody(config).
+		Put(g.getAdminRealmURL(realm, "events", "config"))
 
-	return result, nil
+	return checkForError(resp, err, errMessage)
 }
 
 // GetClientScopesScopeMappingsRealmRolesAvailable returns realm-level roles that are available to attach to this client scope
@@ -4534,6 +6107,167 @@ func (g *GoCloak) DeleteRequiredAction(ctx context.Context, token string, realm
 	return err
 }
 
+// GetRequiredActionConfig gets the configuration of a required action, e.g. the max age of UPDATE_PASSWORD
+func (g *GoCloak) GetRequiredActionConfig(ctx context.Context, token string, realm string, alias string) (*RequiredActionConfigRepresentation, error) {
+	const errMessage = "could not get required action config"
+	var result RequiredActionConfigRepresentation
+
+	if alias == "" {
+		return nil, errors.New("alias is required for getting a required action config")
+	}
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "authentication", "required-actions", alias, "config"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateRequiredActionConfig updates the configuration of a required action
+func (g *GoCloak) UpdateRequiredActionConfig(ctx context.Context, token string, realm string, alias string, config RequiredActionConfigRepresentation) error {
+	const errMessage = "could not update required action config"
+
+	if alias == "" {
+		return errors.New("alias is required for updating a required action config")
+	}
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetBody(config).
+		Put(g.getAdminRealmURL(realm, "authentication", "required-actions", alias, "config"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// DeleteRequiredActionConfig resets the configuration of a required action to its defaults
+func (g *GoCloak) DeleteRequiredActionConfig(ctx context.Context, token string, realm string, alias string) error {
+	const errMessage = "could not delete required action config"
+
+	if alias == "" {
+		return errors.New("alias is required for deleting a required action config")
+	}
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "authentication", "required-actions", alias, "config"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// GetRequiredActionConfigDescription gets the configuration properties supported by a required action
+func (g *GoCloak) GetRequiredActionConfigDescription(ctx context.Context, token string, realm string, alias string) (*RequiredActionConfigInfoRepresentation, error) {
+	const errMessage = "could not get required action config description"
+	var result RequiredActionConfigInfoRepresentation
+
+	if alias == "" {
+		return nil, errors.New("alias is required for getting a required action config description")
+	}
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "authentication", "required-actions", alias, "config-description"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RaiseRequiredActionPriority moves a required action one position up
+func (g *GoCloak) RaiseRequiredActionPriority(ctx context.Context, token string, realm string, alias string) error {
+	const errMessage = "could not raise required action priority"
+
+	if alias == "" {
+		return errors.New("alias is required for raising the priority of a required action")
+	}
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Post(g.getAdminRealmURL(realm, "authentication", "required-actions", alias, "raise-priority"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// LowerRequiredActionPriority moves a required action one position down
+func (g *GoCloak) LowerRequiredActionPriority(ctx context.Context, token string, realm string, alias string) error {
+	const errMessage = "could not lower required action priority"
+
+	if alias == "" {
+		return errors.New("alias is required for lowering the priority of a required action")
+	}
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Post(g.getAdminRealmURL(realm, "authentication", "required-actions", alias, "lower-priority"))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// OrderRequiredActions raises and lowers the priority of the required actions with the given aliases until they are
+// the first required actions of the realm in the given order. The other required actions keep their relative order
+// after them. The required actions are read back afterwards and an error is returned if the server order differs,
+// e.g. because two required actions have the same priority.
+func (g *GoCloak) OrderRequiredActions(ctx context.Context, token string, realm string, aliases []string) error {
+	const errMessage = "could not order required actions"
+
+	current, err := g.getRequiredActionsOrder(ctx, token, realm)
+	if err != nil {
+		return err
+	}
+
+	order := make([]string, 0, len(current))
+	for _, alias := range aliases {
+		if !slices.Contains(current, alias) {
+			return fmt.Errorf("%s: required action %s not found", errMessage, alias)
+		}
+		if slices.Contains(order, alias) {
+			return fmt.Errorf("%s: required action %s listed more than once", errMessage, alias)
+		}
+		order = append(order, alias)
+	}
+	for _, alias := range current {
+		if !slices.Contains(aliases, alias) {
+			order = append(order, alias)
+		}
+	}
+
+	err = reorderByPriority(current, order,
+		func(alias string) error {
+			return g.RaiseRequiredActionPriority(ctx, token, realm, alias)
+		},
+		func(alias string) error {
+			return g.LowerRequiredActionPriority(ctx, token, realm, alias)
+		})
+	if err != nil {
+		return err
+	}
+
+	current, err = g.getRequiredActionsOrder(ctx, token, realm)
+	if err != nil {
+		return err
+	}
+	if len(current) < len(aliases) || !slices.Equal(current[:len(aliases)], aliases) {
+		return fmt.Errorf("%s: required actions are ordered %v instead of %v", errMessage, current, aliases)
+	}
+
+	return nil
+}
+
+// getRequiredActionsOrder returns the aliases of the required actions of the realm ordered by their priority
+func (g *GoCloak) getRequiredActionsOrder(ctx context.Context, token, realm string) ([]string, error) {
+	requiredActions, err := g.GetRequiredActions(ctx, token, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(requiredActions, func(i, j int) bool {
+		return PInt32(requiredActions[i].Priority) < PInt32(requiredActions[j].Priority)
+	})
+	result := make([]string, 0, len(requiredActions))
+	for _, requiredAction := range requiredActions {
+		result = append(result, PString(requiredAction.Alias))
+	}
+
+	return result, nil
+}
+
 // CreateClientScopesScopeMappingsClientRoles attaches a client role to a client scope (not client's scope)
 func (g *GoCloak) CreateClientScopesScopeMappingsClientRoles(
 	ctx context.Context, token, realm, idOfClientScope, idOfClient string, roles []Role,
@@ -5231,3 +6965,122 @@ func (g *GoCloak) RemoveMemberFromOrganizationGroup(ctx context.Context, token,
 
 	return checkForError(resp, err, errMessage)
 }
+
+// -------------------
+// Client Registration
+// -------------------
+
+// CreateClientInitialAccess creates a new initial access token that can be used to register clients
+func (g *GoCloak) CreateClientInitialAccess(ctx context.Context, token, realm string, params ClientInitialAccessCreatePresentation) (*ClientInitialAccessPresentation, error) {
+	const errMessage = "could not create client initial access token"
+
+	var result ClientInitialAccessPresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		SetBody(params).
+		Post(g.getAdminRealmURL(realm, "clients-initial-access"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetClientInitialAccess returns the initial access tokens of a realm
+func (g *GoCloak) GetClientInitialAccess(ctx context.Context, token, realm string) ([]*ClientInitialAccessPresentation, error) {
+	const errMessage = "could not get client initial access tokens"
+
+	var result []*ClientInitialAccessPresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		SetResult(&result).
+		Get(g.getAdminRealmURL(realm, "clients-initial-access"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteClientInitialAccess deletes the initial access token with the given id
+func (g *GoCloak) DeleteClientInitialAccess(ctx context.Context, token, realm, initialAccessID string) error {
+	const errMessage = "could not delete client initial access token"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, token).
+		Delete(g.getAdminRealmURL(realm, "clients-initial-access", initialAccessID))
+
+	return checkForError(resp, err, errMessage)
+}
+
+// RegisterClient registers a new client using the OpenID Connect Dynamic Client Registration endpoint (RFC 7591).
+// The initialAccessToken may be empty if the realm allows anonymous registration from trusted hosts.
+// The returned representation holds the registration access token required for subsequent calls.
+// POST /realms/{realm}/clients-registrations/openid-connect
+func (g *GoCloak) RegisterClient(ctx context.Context, initialAccessToken, realm string, client OIDCClientRepresentation) (*OIDCClientRepresentation, error) {
+	const errMessage = "could not register client"
+
+	var result OIDCClientRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, initialAccessToken).
+		SetResult(&result).
+		SetBody(client).
+		Post(g.getRealmURL(realm, "clients-registrations", "openid-connect"))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetRegisteredClient returns the metadata of a dynamically registered client (RFC 7592)
+// GET /realms/{realm}/clients-registrations/openid-connect/{client-id}
+func (g *GoCloak) GetRegisteredClient(ctx context.Context, registrationAccessToken, realm, clientID string) (*OIDCClientRepresentation, error) {
+	const errMessage = "could not get registered client"
+
+	var result OIDCClientRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, registrationAccessToken).
+		SetResult(&result).
+		Get(g.getRealmURL(realm, "clients-registrations", "openid-connect", clientID))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateRegisteredClient updates the metadata of a dynamically registered client (RFC 7592).
+// Keycloak rotates the registration access token on every update, so the token of the
+// returned representation must be used for subsequent calls.
+// PUT /realms/{realm}/clients-registrations/openid-connect/{client-id}
+func (g *GoCloak) UpdateRegisteredClient(ctx context.Context, registrationAccessToken, realm string, client OIDCClientRepresentation) (*OIDCClientRepresentation, error) {
+	const errMessage = "could not update registered client"
+
+	if NilOrEmpty(client.ClientID) {
+		return nil, errors.New("clientID of a registered client required")
+	}
+
+	var result OIDCClientRepresentation
+	resp, err := g.GetRequestWithBearerAuth(ctx, registrationAccessToken).
+		SetResult(&result).
+		SetBody(client).
+		Put(g.getRealmURL(realm, "clients-registrations", "openid-connect", *client.ClientID))
+
+	if err = checkForError(resp, err, errMessage); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteRegisteredClient deletes a dynamically registered client (RFC 7592)
+// DELETE /realms/{realm}/clients-registrations/openid-connect/{client-id}
+func (g *GoCloak) DeleteRegisteredClient(ctx context.Context, registrationAccessToken, realm, clientID string) error {
+	const errMessage = "could not delete registered client"
+
+	resp, err := g.GetRequestWithBearerAuth(ctx, registrationAccessToken).
+		Delete(g.getRealmURL(realm, "clients-registrations", "openid-connect", clientID))
+
+	return checkForError(resp, err, errMessage)
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -24,6 +25,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/pkcs12"
+	"golang.org/x/mod/semver"
 
 	"github.com/Nerzal/gocloak/v14"
 )
@@ -1486,6 +1488,143 @@ func Test_ClientPermissions(t *testing.T) {
 	require.Equal(t, true, *updatedClientPermissions.Enabled)
 }
 
+func Test_RoleManagementPermissions(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	tearDownRealmRole, realmRoleName := CreateRealmRole(t, client)
+	defer tearDownRealmRole()
+
+	realmRolePermissions, err := client.GetRealmRoleManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		realmRoleName,
+	)
+	require.NoError(t, err, "GetRealmRoleManagementPermissions failed")
+	require.False(t, gocloak.PBool(realmRolePermissions.Enabled))
+
+	realmRolePermissions.Enabled = gocloak.BoolP(true)
+	realmRolePermissions, err = client.UpdateRealmRoleManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		realmRoleName,
+		*realmRolePermissions,
+	)
+	require.NoError(t, err, "UpdateRealmRoleManagementPermissions failed")
+	require.True(t, gocloak.PBool(realmRolePermissions.Enabled))
+
+	realmRole, err := client.GetRealmRole(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		realmRoleName,
+	)
+	require.NoError(t, err, "GetRealmRole failed")
+
+	rolePermissions, err := client.GetRoleByIDManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		*realmRole.ID,
+	)
+	require.NoError(t, err, "GetRoleByIDManagementPermissions failed")
+	require.True(t, gocloak.PBool(rolePermissions.Enabled))
+
+	rolePermissions.Enabled = gocloak.BoolP(false)
+	rolePermissions, err = client.UpdateRoleByIDManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		*realmRole.ID,
+		*rolePermissions,
+	)
+	require.NoError(t, err, "UpdateRoleByIDManagementPermissions failed")
+	require.False(t, gocloak.PBool(rolePermissions.Enabled))
+
+	tearDownClientRole, clientRoleName := CreateClientRole(t, client)
+	defer tearDownClientRole()
+
+	clientRolePermissions, err := client.GetClientRoleManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		clientRoleName,
+	)
+	require.NoError(t, err, "GetClientRoleManagementPermissions failed")
+	require.False(t, gocloak.PBool(clientRolePermissions.Enabled))
+
+	clientRolePermissions.Enabled = gocloak.BoolP(true)
+	clientRolePermissions, err = client.UpdateClientRoleManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		clientRoleName,
+		*clientRolePermissions,
+	)
+	require.NoError(t, err, "UpdateClientRoleManagementPermissions failed")
+	require.True(t, gocloak.PBool(clientRolePermissions.Enabled))
+}
+
+func Test_IdentityProviderManagementPermissions(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	alias := GetRandomName("idp")
+	_, err := client.CreateIdentityProvider(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloak.IdentityProviderRepresentation{
+			ProviderID: gocloak.StringP("github"),
+			Alias:      gocloak.StringP(alias),
+			Enabled:    gocloak.BoolP(true),
+			Config: map[string]string{
+				"clientId":     cfg.GoCloak.ClientID,
+				"clientSecret": cfg.GoCloak.ClientSecret,
+			},
+		},
+	)
+	require.NoError(t, err, "CreateIdentityProvider failed")
+	defer func() {
+		err := client.DeleteIdentityProvider(
+			context.Background(),
+			token.AccessToken,
+			cfg.GoCloak.Realm,
+			alias,
+		)
+		require.NoError(t, err, "DeleteIdentityProvider failed")
+	}()
+
+	permissions, err := client.GetIdentityProviderManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		alias,
+	)
+	require.NoError(t, err, "GetIdentityProviderManagementPermissions failed")
+	require.False(t, gocloak.PBool(permissions.Enabled))
+
+	permissions.Enabled = gocloak.BoolP(true)
+	permissions, err = client.UpdateIdentityProviderManagementPermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		alias,
+		*permissions,
+	)
+	require.NoError(t, err, "UpdateIdentityProviderManagementPermissions failed")
+	require.True(t, gocloak.PBool(permissions.Enabled))
+	require.NotEmpty(t, permissions.ScopePermissions)
+}
+
 func Test_CreateClientRole(t *testing.T) {
 	t.Parallel()
 	client := NewClientWithDebug(t)
@@ -2836,6 +2975,56 @@ func Test_RevokeUserConsents(t *testing.T) {
 	require.NoError(t, err, "Consent revocation failed")
 }
 
+func Test_GetUserConsentsAndRevokeClientsConsents(t *testing.T) {
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	SetUpTestUser(t, client)
+	_, err := client.GetToken(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.TokenOptions{
+			ClientID:     &cfg.GoCloak.ClientID,
+			ClientSecret: &cfg.GoCloak.ClientSecret,
+			Username:     &cfg.GoCloak.UserName,
+			Password:     &cfg.GoCloak.Password,
+			GrantType:    gocloak.StringP("password"),
+			Scopes:       []string{"openid", "offline_access"},
+		},
+	)
+	require.NoError(t, err, "Login failed")
+	token := GetAdminToken(t, client)
+
+	findConsent := func() *gocloak.UserConsentRepresentation {
+		consents, err := client.GetUserConsents(
+			context.Background(),
+			token.AccessToken,
+			cfg.GoCloak.Realm,
+			testUserID,
+		)
+		require.NoError(t, err, "GetUserConsents failed")
+		for _, consent := range consents {
+			if gocloak.PString(consent.ClientID) == cfg.GoCloak.ClientID {
+				return consent
+			}
+		}
+		return nil
+	}
+
+	consent := findConsent()
+	require.NotNil(t, consent, "offline token of the client not listed")
+	require.NotEmpty(t, consent.AdditionalGrants)
+
+	revoked, err := client.RevokeClientsConsents(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		[]string{cfg.GoCloak.ClientID},
+	)
+	require.NoError(t, err, "RevokeClientsConsents failed")
+	require.GreaterOrEqual(t, revoked, 1)
+	require.Nil(t, findConsent())
+}
+
 func Test_LogoutUserSession(t *testing.T) {
 	cfg := GetConfig(t)
 	client := NewClientWithDebug(t)
@@ -2949,6 +3138,268 @@ func Test_UpdateRealm(t *testing.T) {
 	require.NoError(t, err, "UpdateRealm failed")
 }
 
+// EnableAdminPermissionsOrSkip enables the fine-grained admin permissions v2 in the realm and skips the test
+// if the server does not support them, i.e. if it is older than 26.2 or runs with admin-fine-grained-authz:v1
+func EnableAdminPermissionsOrSkip(t *testing.T, client gocloak.GoCloakIface, token, realmID string) {
+	version, err := client.GetServerVersion(context.Background(), token)
+	require.NoError(t, err, "GetServerVersion failed")
+	if semver.Compare("v"+version, "v26.2.0") < 0 {
+		t.Skipf("fine-grained admin permissions v2 require keycloak 26.2.0 or later, got %s", version)
+	}
+
+	realm, err := client.GetRealm(context.Background(), token, realmID)
+	require.NoError(t, err, "GetRealm failed")
+	realm.AdminPermissionsEnabled = gocloak.BoolP(true)
+	err = client.UpdateRealm(context.Background(), token, *realm)
+	require.NoError(t, err, "UpdateRealm failed")
+
+	realm, err = client.GetRealm(context.Background(), token, realmID)
+	require.NoError(t, err, "GetRealm failed")
+	if !gocloak.PBool(realm.AdminPermissionsEnabled) {
+		t.Skip("fine-grained admin permissions v2 are not available, the admin-fine-grained-authz:v2 feature is disabled")
+	}
+}
+
+func Test_AdminPermissions(t *testing.T) {
+	t.Parallel()
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	tearDown, realmID := CreateRealm(t, client)
+	defer tearDown()
+
+	_, err := client.GetAdminPermissionsClient(ctx, token.AccessToken, realmID)
+	require.Error(t, err, "GetAdminPermissionsClient should fail if admin permissions are disabled")
+
+	EnableAdminPermissionsOrSkip(t, client, token.AccessToken, realmID)
+
+	adminPermissionsClient, err := client.GetAdminPermissionsClient(ctx, token.AccessToken, realmID)
+	require.NoError(t, err, "GetAdminPermissionsClient failed")
+	require.Equal(t, "admin-permissions", gocloak.PString(adminPermissionsClient.ClientID))
+
+	resourceServer, err := client.GetResourceServer(ctx, token.AccessToken, realmID, *adminPermissionsClient.ID)
+	require.NoError(t, err, "GetResourceServer failed")
+	require.NotNil(t, resourceServer.AuthorizationSchema)
+	require.Contains(t, resourceServer.AuthorizationSchema.ResourceTypes, string(gocloak.AdminPermissionResourceTypeUsers))
+
+	userID, err := client.CreateUser(ctx, token.AccessToken, realmID, gocloak.User{
+		Username: GetRandomNameP("User"),
+		Enabled:  gocloak.BoolP(true),
+	})
+	require.NoError(t, err, "CreateUser failed")
+
+	policy, err := client.CreatePolicy(ctx, token.AccessToken, realmID, *adminPermissionsClient.ID, gocloak.PolicyRepresentation{
+		Name: GetRandomNameP("PolicyName"),
+		Type: gocloak.StringP("user"),
+		UserPolicyRepresentation: gocloak.UserPolicyRepresentation{
+			Users: []string{userID},
+		},
+	})
+	require.NoError(t, err, "CreatePolicy failed")
+
+	_, err = client.CreateAdminPermission(ctx, token.AccessToken, realmID, *adminPermissionsClient.ID, gocloak.PermissionRepresentation{
+		Name:   GetRandomNameP("PermissionName"),
+		Scopes: []string{"view"},
+	})
+	require.Error(t, err, "CreateAdminPermission should fail without resource type")
+
+	permission, err := client.CreateAdminPermission(ctx, token.AccessToken, realmID, *adminPermissionsClient.ID, gocloak.PermissionRepresentation{
+		Name:         GetRandomNameP("PermissionName"),
+		ResourceType: gocloak.StringP(string(gocloak.AdminPermissionResourceTypeUsers)),
+		Scopes:       []string{"view"},
+		Policies:     []string{*policy.ID},
+	})
+	require.NoError(t, err, "CreateAdminPermission failed")
+
+	permissions, err := client.GetAdminPermissions(ctx, token.AccessToken, realmID, *adminPermissionsClient.ID, gocloak.GetPermissionParams{
+		ResourceType: gocloak.StringP(string(gocloak.AdminPermissionResourceTypeUsers)),
+	})
+	require.NoError(t, err, "GetAdminPermissions failed")
+	require.True(t, slices.ContainsFunc(permissions, func(p *gocloak.PermissionRepresentation) bool {
+		return gocloak.PString(p.ID) == *permission.ID
+	}))
+
+	evaluation, err := client.EvaluateAdminPermissions(ctx, token.AccessToken, realmID, *adminPermissionsClient.ID, gocloak.PolicyEvaluationRequest{
+		UserID:       gocloak.StringP(userID),
+		ResourceType: gocloak.StringP(string(gocloak.AdminPermissionResourceTypeUsers)),
+	})
+	require.NoError(t, err, "EvaluateAdminPermissions failed")
+	require.NotNil(t, evaluation.Status)
+	require.Equal(t, gocloak.Permit, *evaluation.Status)
+}
+
+func Test_RealmLocalizationTexts(t *testing.T) {
+	t.Parallel()
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	tearDown, realm := CreateRealm(t, client)
+	defer tearDown()
+
+	err := client.ImportRealmLocalizationTexts(
+		ctx,
+		token.AccessToken,
+		realm,
+		"de",
+		map[string]string{
+			"loginTitle": "Anmelden",
+			"doLogIn":    "Einloggen",
+		})
+	require.NoError(t, err, "ImportRealmLocalizationTexts failed")
+
+	err = client.UpdateRealmLocalizationText(ctx, token.AccessToken, realm, "de", "doLogIn", "Anmelden!")
+	require.NoError(t, err, "UpdateRealmLocalizationText failed")
+
+	text, err := client.GetRealmLocalizationText(ctx, token.AccessToken, realm, "de", "doLogIn")
+	require.NoError(t, err, "GetRealmLocalizationText failed")
+	require.Equal(t, "Anmelden!", text)
+
+	locales, err := client.GetRealmLocalizationLocales(ctx, token.AccessToken, realm)
+	require.NoError(t, err, "GetRealmLocalizationLocales failed")
+	require.Contains(t, locales, "de")
+
+	err = client.SyncRealmLocalizationTexts(
+		ctx,
+		token.AccessToken,
+		realm,
+		map[string]map[string]string{
+			"de": {
+				"doLogIn":  "Anmelden",
+				"doSubmit": "Absenden",
+			},
+			"fr": {
+				"doLogIn": "Connexion",
+			},
+		})
+	require.NoError(t, err, "SyncRealmLocalizationTexts failed")
+
+	texts, err := client.GetRealmLocalizationTexts(ctx, token.AccessToken, realm, "de")
+	require.NoError(t, err, "GetRealmLocalizationTexts failed")
+	require.Equal(t, map[string]string{"doLogIn": "Anmelden", "doSubmit": "Absenden"}, texts)
+
+	texts, err = client.GetRealmLocalizationTexts(ctx, token.AccessToken, realm, "fr")
+	require.NoError(t, err, "GetRealmLocalizationTexts failed")
+	require.Equal(t, map[string]string{"doLogIn": "Connexion"}, texts)
+
+	err = client.DeleteRealmLocalizationText(ctx, token.AccessToken, realm, "fr", "doLogIn")
+	require.NoError(t, err, "DeleteRealmLocalizationText failed")
+
+	err = client.DeleteRealmLocalizationTexts(ctx, token.AccessToken, realm, "de")
+	require.NoError(t, err, "DeleteRealmLocalizationTexts failed")
+
+	texts, err = client.GetRealmLocalizationTexts(ctx, token.AccessToken, realm, "de")
+	require.NoError(t, err, "GetRealmLocalizationTexts failed")
+	require.Empty(t, texts)
+}
+
+func Test_GetUpdateEventsConfig(t *testing.T) {
+	t.Parallel()
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	tearDown, realm := CreateRealm(t, client)
+	defer tearDown()
+
+	err := client.UpdateEventsConfig(
+		ctx,
+		token.AccessToken,
+		realm,
+		gocloak.RealmEventsConfigRepresentation{
+			EventsEnabled:             gocloak.BoolP(true),
+			EventsExpiration:          gocloak.Int64P(3600),
+			EventsListeners:           []string{"jboss-logging"},
+			EnabledEventTypes:         []string{"LOGIN", "LOGIN_ERROR"},
+			AdminEventsEnabled:        gocloak.BoolP(true),
+			AdminEventsDetailsEnabled: gocloak.BoolP(true),
+		})
+	require.NoError(t, err, "UpdateEventsConfig failed")
+
+	config, err := client.GetEventsConfig(ctx, token.AccessToken, realm)
+	require.NoError(t, err, "GetEventsConfig failed")
+	require.True(t, gocloak.PBool(config.EventsEnabled))
+	require.Equal(t, int64(3600), gocloak.PInt64(config.EventsExpiration))
+	require.ElementsMatch(t, []string{"LOGIN", "LOGIN_ERROR"}, config.EnabledEventTypes)
+	require.True(t, gocloak.PBool(config.AdminEventsEnabled))
+	require.True(t, gocloak.PBool(config.AdminEventsDetailsEnabled))
+
+	err = client.ClearEvents(ctx, token.AccessToken, realm)
+	require.NoError(t, err, "ClearEvents failed")
+
+	err = client.ClearAdminEvents(ctx, token.AccessToken, realm)
+	require.NoError(t, err, "ClearAdminEvents failed")
+
+	events, err := client.GetEvents(ctx, token.AccessToken, realm, gocloak.GetEventsParams{})
+	require.NoError(t, err, "GetEvents failed")
+	require.Empty(t, events)
+}
+
+func Test_TailAdminEvents(t *testing.T) {
+	t.Parallel()
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	tearDown, realm := CreateRealm(t, client)
+	defer tearDown()
+
+	err := client.UpdateEventsConfig(
+		ctx,
+		token.AccessToken,
+		realm,
+		gocloak.RealmEventsConfigRepresentation{
+			AdminEventsEnabled:        gocloak.BoolP(true),
+			AdminEventsDetailsEnabled: gocloak.BoolP(true),
+		})
+	require.NoError(t, err, "UpdateEventsConfig failed")
+
+	events := client.TailAdminEvents(
+		ctx,
+		gocloak.StaticToken(token.AccessToken),
+		realm,
+		gocloak.GetAdminEventsParams{},
+		gocloak.TailEventsOptions{
+			Cursor:       &gocloak.EventCursor{},
+			PollInterval: 500 * time.Millisecond,
+			PageSize:     2,
+			OnError: func(err error) {
+				t.Logf("TailAdminEvents failed: %v", err)
+			},
+		})
+
+	var groupIDs []string
+	for i := 0; i < 3; i++ {
+		groupID, err := client.CreateGroup(ctx, token.AccessToken, realm, gocloak.Group{
+			Name: gocloak.StringP(GetRandomName("GroupName")),
+		})
+		require.NoError(t, err, "CreateGroup failed")
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	var resourcePaths []string
+	var lastTime int64
+	for len(resourcePaths) < len(groupIDs) {
+		event, ok := <-events
+		require.True(t, ok, "TailAdminEvents stopped before all events were received")
+		require.GreaterOrEqual(t, event.Event.Time, lastTime)
+		require.Equal(t, event.Event.Time, event.Cursor.Time)
+		lastTime = event.Event.Time
+		if gocloak.PString(event.Event.OperationType) == "CREATE" && gocloak.PString(event.Event.ResourceType) == "GROUP" {
+			resourcePaths = append(resourcePaths, gocloak.PString(event.Event.ResourcePath))
+		}
+	}
+	for i, groupID := range groupIDs {
+		require.Equal(t, "groups/"+groupID, resourcePaths[i])
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
 func Test_ClearRealmCache(t *testing.T) {
 	t.Parallel()
 	client := NewClientWithDebug(t)
@@ -3435,6 +3886,68 @@ func Test_GetUserBruteForceDetectionStatus(t *testing.T) {
 	}, 5*time.Second, 100*time.Millisecond, "brute force status should clear after successful login")
 }
 
+func Test_ClearLoginFailuresAndGetLockedUsers(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	tearDown, realmName := CreateRealm(t, client)
+	defer tearDown()
+
+	realm, err := client.GetRealm(ctx, token.AccessToken, realmName)
+	require.NoError(t, err, "GetRealm failed")
+	realm.Enabled = gocloak.BoolP(true)
+	realm.BruteForceProtected = gocloak.BoolP(true)
+	realm.FailureFactor = gocloak.IntP(1)
+	realm.WaitIncrementSeconds = gocloak.IntP(600)
+	realm.MaxFailureWaitSeconds = gocloak.IntP(600)
+	err = client.UpdateRealm(ctx, token.AccessToken, *realm)
+	require.NoError(t, err, "UpdateRealm failed")
+
+	username := GetRandomName("user")
+	userID, err := client.CreateUser(ctx, token.AccessToken, realmName, gocloak.User{
+		Username:      gocloak.StringP(username),
+		Email:         gocloak.StringP(username + "@localhost.com"),
+		EmailVerified: gocloak.BoolP(true),
+		FirstName:     GetRandomNameP("FirstName"),
+		LastName:      GetRandomNameP("LastName"),
+		Enabled:       gocloak.BoolP(true),
+	})
+	require.NoError(t, err, "CreateUser failed")
+	err = client.SetPassword(ctx, token.AccessToken, userID, realmName, cfg.GoCloak.Password, false)
+	require.NoError(t, err, "SetPassword failed")
+
+	lockUser := func() {
+		_, err := client.Login(ctx, "admin-cli", "", realmName, username, "wrong password")
+		require.Error(t, err, "Login with wrong password succeeded")
+		// Keycloak 26+ processes brute-force events asynchronously
+		require.Eventually(t, func() bool {
+			lockedUsers, err := client.GetLockedUsers(ctx, token.AccessToken, realmName, gocloak.GetUsersParams{})
+			return err == nil && len(lockedUsers) == 1 && gocloak.PString(lockedUsers[0].User.ID) == userID
+		}, 5*time.Second, 100*time.Millisecond, "user should be locked")
+	}
+
+	isUnlocked := func() bool {
+		lockedUsers, err := client.GetLockedUsers(ctx, token.AccessToken, realmName, gocloak.GetUsersParams{})
+		return err == nil && len(lockedUsers) == 0
+	}
+
+	lockUser()
+	err = client.ClearUserLoginFailures(ctx, token.AccessToken, realmName, userID)
+	require.NoError(t, err, "ClearUserLoginFailures failed")
+	require.Eventually(t, isUnlocked, 5*time.Second, 100*time.Millisecond, "user should be unlocked")
+
+	lockUser()
+	err = client.ClearAllLoginFailures(ctx, token.AccessToken, realmName)
+	require.NoError(t, err, "ClearAllLoginFailures failed")
+	require.Eventually(t, isUnlocked, 5*time.Second, 100*time.Millisecond, "all users should be unlocked")
+
+	_, err = client.Login(ctx, "admin-cli", "", realmName, username, cfg.GoCloak.Password)
+	require.NoError(t, err, "Login failed")
+}
+
 func Test_CreateUserCustomAttributes(t *testing.T) {
 	cfg := GetConfig(t)
 	client := NewClientWithDebug(t)
@@ -3807,17 +4320,85 @@ func Test_GetUsersByClientRoleName(t *testing.T) {
 	require.Equal(t, userID, *users[0].ID)
 }
 
-func Test_GetUserSessions(t *testing.T) {
+func Test_ImpersonateUser(t *testing.T) {
+	t.Parallel()
 	cfg := GetConfig(t)
 	client := NewClientWithDebug(t)
-	SetUpTestUser(t, client)
-	_, err := client.GetToken(
+	token := GetAdminToken(t, client)
+
+	tearDown, userID := CreateUser(t, client)
+	defer tearDown()
+
+	impersonation, err := client.ImpersonateUser(
 		context.Background(),
+		token.AccessToken,
 		cfg.GoCloak.Realm,
-		gocloak.TokenOptions{
-			ClientID:     &cfg.GoCloak.ClientID,
-			ClientSecret: &cfg.GoCloak.ClientSecret,
-			Username:     &cfg.GoCloak.UserName,
+		userID,
+	)
+	require.NoError(t, err, "ImpersonateUser failed")
+	require.False(t, gocloak.PBool(impersonation.SameRealm))
+	require.Contains(t, gocloak.PString(impersonation.Redirect), "/realms/"+cfg.GoCloak.Realm+"/account")
+	require.NotEmpty(t, impersonation.Cookies)
+}
+
+func Test_GetUserProfileMetadataAndUnmanagedAttributes(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	metadata, err := client.GetUserProfileMetadata(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+	)
+	require.NoError(t, err, "GetUserProfileMetadata failed")
+	var names []string
+	for _, attribute := range metadata.Attributes {
+		names = append(names, gocloak.PString(attribute.Name))
+	}
+	require.Contains(t, names, "username")
+	require.Contains(t, names, "email")
+
+	tearDown, userID := CreateUser(t, client)
+	defer tearDown()
+
+	user, err := client.GetUserByIDWithProfileMetadata(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		userID,
+	)
+	require.NoError(t, err, "GetUserByIDWithProfileMetadata failed")
+	require.NotNil(t, user.UserProfileMetadata)
+	require.NotEmpty(t, user.UserProfileMetadata.Attributes)
+
+	err = user.UserProfileMetadata.ValidateUserUpdate(user, user)
+	require.NoError(t, err, "ValidateUserUpdate failed")
+
+	attributes, err := client.GetUserUnmanagedAttributes(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		userID,
+	)
+	require.NoError(t, err, "GetUserUnmanagedAttributes failed")
+	for name := range attributes {
+		require.NotContains(t, names, name)
+	}
+}
+
+func Test_GetUserSessions(t *testing.T) {
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	SetUpTestUser(t, client)
+	_, err := client.GetToken(
+		context.Background(),
+		cfg.GoCloak.Realm,
+		gocloak.TokenOptions{
+			ClientID:     &cfg.GoCloak.ClientID,
+			ClientSecret: &cfg.GoCloak.ClientSecret,
+			Username:     &cfg.GoCloak.UserName,
 			Password:     &cfg.GoCloak.Password,
 			GrantType:    gocloak.StringP("password"),
 		},
@@ -4139,6 +4720,244 @@ func Test_ClientSecret(t *testing.T) {
 	require.NoError(t, err, "DeleteClient failed")
 }
 
+func Test_RotateClientSecret(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	testClient := gocloak.Client{
+		ClientID:                GetRandomNameP("gocloak-client-secret-rotation-client-id-"),
+		ServiceAccountsEnabled:  gocloak.BoolP(true),
+		Enabled:                 gocloak.BoolP(true),
+		Protocol:                gocloak.StringP("openid-connect"),
+		ClientAuthenticatorType: gocloak.StringP("client-secret"),
+	}
+	tearDown, idOfClient := CreateClient(t, client, &testClient)
+	defer tearDown()
+
+	before, err := client.GetClientSecretRotationInfo(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+	)
+	require.NoError(t, err, "GetClientSecretRotationInfo failed")
+	require.NotNil(t, before.Secret)
+
+	after, err := client.RotateClientSecret(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+	)
+	require.NoError(t, err, "RotateClientSecret failed")
+	require.NotNil(t, after.Secret)
+	require.NotEqual(t, gocloak.PString(before.Secret.Value), gocloak.PString(after.Secret.Value))
+
+	// without a secret-rotation client policy Keycloak does not keep the previous secret
+	require.Nil(t, after.RotatedSecret)
+	_, err = client.GetClientRotatedSecret(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+	)
+	require.Error(t, err, "GetClientRotatedSecret should fail without a rotated secret")
+}
+
+func Test_ClientCertificate(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	testClient := gocloak.Client{
+		ClientID:                GetRandomNameP("gocloak-client-certificate-client-id-"),
+		ClientAuthenticatorType: gocloak.StringP("client-jwt"),
+		ServiceAccountsEnabled:  gocloak.BoolP(true),
+		Enabled:                 gocloak.BoolP(true),
+		Protocol:                gocloak.StringP("openid-connect"),
+		PublicClient:            gocloak.BoolP(false),
+	}
+	tearDown, idOfClient := CreateClient(t, client, &testClient)
+	defer tearDown()
+
+	generated, err := client.GenerateClientCertificate(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+		"jwt.credential",
+	)
+	require.NoError(t, err, "GenerateClientCertificate failed")
+	require.NotEmpty(t, gocloak.PString(generated.Certificate))
+
+	certificate, err := client.GetClientCertificate(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+		"jwt.credential",
+	)
+	require.NoError(t, err, "GetClientCertificate failed")
+	require.Equal(t, gocloak.PString(generated.Certificate), gocloak.PString(certificate.Certificate))
+
+	format := gocloak.KeystoreFormatPKCS12
+	keystore, err := client.GenerateAndDownloadClientCertificate(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+		"jwt.credential",
+		gocloak.ClientKeyStoreConfig{
+			Format:        &format,
+			KeyAlias:      gocloak.StringP("gocloak"),
+			KeyPassword:   gocloak.StringP("secret"),
+			StorePassword: gocloak.StringP("secret"),
+		},
+	)
+	require.NoError(t, err, "GenerateAndDownloadClientCertificate failed")
+	require.NotEmpty(t, keystore, "GenerateAndDownloadClientCertificate returned an empty keystore")
+
+	f, err := os.Open(filepath.Join("testdata", "keystore.p12"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	uploaded, err := client.UploadClientCertificate(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+		"jwt.credential",
+		gocloak.UploadClientCertificateParams{
+			KeystoreFormat: &format,
+			KeyAlias:       gocloak.StringP("signedJWT"),
+			KeyPassword:    gocloak.StringP("secret"),
+			StorePassword:  gocloak.StringP("secret"),
+		},
+		"keystore.p12",
+		f,
+	)
+	require.NoError(t, err, "UploadClientCertificate failed")
+	require.NotEmpty(t, gocloak.PString(uploaded.Certificate))
+	require.NotEqual(t, gocloak.PString(generated.Certificate), gocloak.PString(uploaded.Certificate))
+
+	pemFormat := gocloak.KeystoreFormatCertificatePEM
+	pemCert := "-----BEGIN CERTIFICATE-----\n" + gocloak.PString(generated.Certificate) + "\n-----END CERTIFICATE-----\n"
+	uploaded, err = client.UploadClientCertificateOnly(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+		"jwt.credential",
+		gocloak.UploadClientCertificateParams{
+			KeystoreFormat: &pemFormat,
+		},
+		"certificate.pem",
+		strings.NewReader(pemCert),
+	)
+	require.NoError(t, err, "UploadClientCertificateOnly failed")
+	require.Equal(t, gocloak.PString(generated.Certificate), gocloak.PString(uploaded.Certificate))
+
+	_, err = client.UploadClientCertificate(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		idOfClient,
+		"jwt.credential",
+		gocloak.UploadClientCertificateParams{},
+		"certificate.pem",
+		strings.NewReader(pemCert),
+	)
+	require.Error(t, err, "UploadClientCertificate should fail without a keystore format")
+}
+
+func Test_EvaluateScopes(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	SetUpTestUser(t, client)
+	ctx := context.Background()
+
+	params := gocloak.EvaluateScopesParams{
+		Scope:  gocloak.StringP("openid profile email"),
+		UserID: gocloak.StringP(testUserID),
+	}
+
+	accessToken, err := client.GenerateExampleAccessToken(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		params,
+	)
+	require.NoError(t, err, "GenerateExampleAccessToken failed")
+	require.Equal(t, testUserID, accessToken["sub"])
+	require.Equal(t, cfg.GoCloak.UserName, accessToken["preferred_username"])
+
+	idToken, err := client.GenerateExampleIDToken(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		params,
+	)
+	require.NoError(t, err, "GenerateExampleIDToken failed")
+	require.Equal(t, "ID", idToken["typ"])
+
+	userInfo, err := client.GenerateExampleUserInfo(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		params,
+	)
+	require.NoError(t, err, "GenerateExampleUserInfo failed")
+	require.Equal(t, testUserID, userInfo["sub"])
+
+	mappers, err := client.GetEvaluateScopesProtocolMappers(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		params,
+	)
+	require.NoError(t, err, "GetEvaluateScopesProtocolMappers failed")
+	require.NotEmpty(t, mappers)
+
+	granted, err := client.GetEvaluateScopesGrantedScopeMappings(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		cfg.GoCloak.Realm,
+		params,
+	)
+	require.NoError(t, err, "GetEvaluateScopesGrantedScopeMappings failed")
+
+	require.NotEmpty(t, granted)
+
+	notGranted, err := client.GetEvaluateScopesNotGrantedScopeMappings(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		cfg.GoCloak.Realm,
+		params,
+	)
+	require.NoError(t, err, "GetEvaluateScopesNotGrantedScopeMappings failed")
+	for _, role := range notGranted {
+		for _, grantedRole := range granted {
+			require.NotEqual(t, gocloak.PString(grantedRole.ID), gocloak.PString(role.ID))
+		}
+	}
+}
+
 func Test_ClientServiceAccount(t *testing.T) {
 	t.Parallel()
 	cfg := GetConfig(t)
@@ -5269,6 +6088,101 @@ func Test_ErrorsCreateListGetUpdateDeleteResourceClient(t *testing.T) {
 	require.Error(t, err, "UpdateResourceClient no error on unauthorized request")
 }
 
+func Test_ProtectionClient(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+
+	tearDownUser, userID := CreateUser(t, client)
+	defer tearDownUser()
+
+	protection := gocloak.NewProtectionClient(client, cfg.GoCloak.Realm, cfg.GoCloak.ClientID, cfg.GoCloak.ClientSecret)
+
+	token, err := protection.Token(context.Background())
+	require.NoError(t, err, "Token failed")
+	require.NotEmpty(t, token)
+
+	cachedToken, err := protection.Token(context.Background())
+	require.NoError(t, err, "Token failed")
+	require.Equal(t, token, cachedToken, "Token should reuse a valid PAT")
+
+	resourceType := GetRandomName("urn:gocloak:resources:protection:")
+	created, err := protection.CreateResource(context.Background(), gocloak.ResourceRepresentation{
+		Name: GetRandomNameP("ResourceName"),
+		Type: gocloak.StringP(resourceType),
+		URIs: []string{"/protection/1"},
+		ResourceScopes: []gocloak.ScopeRepresentation{
+			{Name: gocloak.StringP("message-view")},
+		},
+		OwnerManagedAccess: gocloak.BoolP(true),
+	})
+	require.NoError(t, err, "CreateResource failed")
+	defer func() {
+		err := protection.DeleteResource(context.Background(), *created.ID)
+		require.NoError(t, err, "DeleteResource failed")
+	}()
+
+	resources, err := protection.GetResources(context.Background(), gocloak.GetResourceParams{
+		Type: gocloak.StringP(resourceType),
+	})
+	require.NoError(t, err, "GetResources failed")
+	require.Len(t, resources, 1)
+	require.Equal(t, *created.ID, *resources[0].ID)
+	require.Equal(t, []string{"/protection/1"}, resources[0].URIs)
+
+	created.URIs = []string{"/protection/2"}
+	err = protection.UpdateResource(context.Background(), *created)
+	require.NoError(t, err, "UpdateResource failed")
+
+	resource, err := protection.GetResource(context.Background(), *created.ID)
+	require.NoError(t, err, "GetResource failed")
+	require.Equal(t, []string{"/protection/2"}, resource.URIs)
+
+	// user-managed access policies are managed by the owner of the resource
+	ownerToken := GetUserToken(t, client)
+	owned, err := protection.CreateResource(context.Background(), gocloak.ResourceRepresentation{
+		Name:  GetRandomNameP("ResourceName"),
+		Type:  gocloak.StringP(resourceType),
+		Owner: &gocloak.ResourceOwnerRepresentation{Name: gocloak.StringP(cfg.GoCloak.UserName)},
+		ResourceScopes: []gocloak.ScopeRepresentation{
+			{Name: gocloak.StringP("message-view")},
+			{Name: gocloak.StringP("message-post")},
+		},
+		OwnerManagedAccess: gocloak.BoolP(true),
+	})
+	require.NoError(t, err, "CreateResource failed")
+	defer func() {
+		err := protection.DeleteResource(context.Background(), *owned.ID)
+		require.NoError(t, err, "DeleteResource failed")
+	}()
+
+	owner := gocloak.StaticToken(ownerToken.AccessToken)
+	policy, err := protection.CreateResourcePolicy(context.Background(), owner, *owned.ID, gocloak.ResourcePolicyRepresentation{
+		Name:   GetRandomNameP("PolicyName"),
+		Scopes: []string{"message-view"},
+		Users:  []string{userID},
+	})
+	require.NoError(t, err, "CreateResourcePolicy failed")
+
+	policy.Scopes = []string{"message-post"}
+	err = protection.UpdateResourcePolicy(context.Background(), owner, *policy.ID, *policy)
+	require.NoError(t, err, "UpdateResourcePolicy failed")
+
+	policy, err = protection.GetResourcePolicy(context.Background(), owner, *policy.ID)
+	require.NoError(t, err, "GetResourcePolicy failed")
+	require.Equal(t, []string{"message-post"}, policy.Scopes)
+
+	policies, err := protection.GetResourcePolicies(context.Background(), owner, gocloak.GetResourcePoliciesParams{
+		ResourceID: owned.ID,
+	})
+	require.NoError(t, err, "GetResourcePolicies failed")
+	require.Len(t, policies, 1)
+	require.Equal(t, *policy.ID, *policies[0].ID)
+
+	err = protection.DeleteResourcePolicy(context.Background(), owner, *policy.ID)
+	require.NoError(t, err, "DeleteResourcePolicy failed")
+}
+
 func Test_CreateListGetUpdateDeleteResourceClient(t *testing.T) {
 	t.Parallel()
 	cfg := GetConfig(t)
@@ -5351,6 +6265,95 @@ func Test_GetResourceServer(t *testing.T) {
 	t.Logf("Resource server settings: %+v", rs)
 }
 
+func Test_ExportImportUpdateResourceServer(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	newAuthzClient := func() *gocloak.Client {
+		return &gocloak.Client{
+			ClientID:                     GetRandomNameP("ClientID"),
+			ServiceAccountsEnabled:       gocloak.BoolP(true),
+			AuthorizationServicesEnabled: gocloak.BoolP(true),
+			PublicClient:                 gocloak.BoolP(false),
+		}
+	}
+	tearDownSource, sourceID := CreateClient(t, client, newAuthzClient())
+	defer tearDownSource()
+	tearDownTarget, targetID := CreateClient(t, client, newAuthzClient())
+	defer tearDownTarget()
+
+	_, resourceID := CreateResource(t, client, sourceID)
+	_, policyID := CreatePolicy(t, client, sourceID, gocloak.PolicyRepresentation{
+		Name: GetRandomNameP("PolicyName"),
+		Type: gocloak.StringP("client"),
+		ClientPolicyRepresentation: gocloak.ClientPolicyRepresentation{
+			Clients: []string{gocloakClientID},
+		},
+	})
+	permissionName := GetRandomName("PermissionName")
+	_, _ = CreatePermission(t, client, sourceID, gocloak.PermissionRepresentation{
+		Name:      gocloak.StringP(permissionName),
+		Type:      gocloak.StringP("resource"),
+		Policies:  []string{policyID},
+		Resources: []string{resourceID},
+	})
+
+	export, err := client.GetResourceServer(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		sourceID,
+	)
+	require.NoError(t, err, "GetResourceServer failed")
+
+	err = client.ImportResourceServer(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		targetID,
+		*export,
+	)
+	require.NoError(t, err, "ImportResourceServer failed")
+
+	err = client.UpdateResourceServer(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		targetID,
+		gocloak.ResourceServerRepresentation{
+			PolicyEnforcementMode:         gocloak.Ptr(gocloak.Permissive),
+			DecisionStrategy:              gocloak.Ptr(gocloak.Affirmative),
+			AllowRemoteResourceManagement: gocloak.BoolP(true),
+		},
+	)
+	require.NoError(t, err, "UpdateResourceServer failed")
+
+	imported, err := client.GetResourceServer(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		targetID,
+	)
+	require.NoError(t, err, "GetResourceServer failed")
+	require.Equal(t, gocloak.Permissive, *imported.PolicyEnforcementMode)
+	require.Equal(t, gocloak.Affirmative, *imported.DecisionStrategy)
+	require.True(t, gocloak.PBool(imported.AllowRemoteResourceManagement))
+
+	var resourceNames, policyNames []string
+	for _, resource := range imported.Resources {
+		resourceNames = append(resourceNames, gocloak.PString(resource.Name))
+	}
+	for _, policy := range imported.Policies {
+		policyNames = append(policyNames, gocloak.PString(policy.Name))
+	}
+	for _, resource := range export.Resources {
+		require.Contains(t, resourceNames, gocloak.PString(resource.Name))
+	}
+	require.Contains(t, policyNames, permissionName)
+}
+
 func Test_CreateListGetUpdateDeleteResource(t *testing.T) {
 	t.Parallel()
 	cfg := GetConfig(t)
@@ -5615,33 +6618,120 @@ func Test_ErrorsGetAuthorizationPolicyAssociatedPolicies(t *testing.T) {
 	resource, resourceID := CreateResource(t, client, gocloakClientID)
 
 	// Create Permission
-	permission, permissionID := CreatePermission(t, client, gocloakClientID, gocloak.PermissionRepresentation{
-		Name:        GetRandomNameP("PermissionName"),
-		Description: gocloak.StringP("Permission Description"),
-		Resources: []string{
-			resourceID,
-		},
-		Policies: []string{
-			parentPolicyID,
-		},
-		Type: gocloak.StringP("resource"),
+	permission, permissionID := CreatePermission(t, client, gocloakClientID, gocloak.PermissionRepresentation{
+		Name:        GetRandomNameP("PermissionName"),
+		Description: gocloak.StringP("Permission Description"),
+		Resources: []string{
+			resourceID,
+		},
+		Policies: []string{
+			parentPolicyID,
+		},
+		Type: gocloak.StringP("resource"),
+	})
+
+	func() {
+		permission()
+		resource()
+		policy()
+	}()
+
+	// List Polices
+	_, err := client.GetAuthorizationPolicyAssociatedPolicies(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		permissionID,
+	)
+	require.Error(t, err, "GetAuthorizationPolicyAssociatedPolicies no error")
+}
+
+func Test_SearchPermissionAndPolicy(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	// Create Policy
+	policyName := GetRandomName("PolicyName")
+	tearDownPolicy, policyID := CreatePolicy(t, client, gocloakClientID, gocloak.PolicyRepresentation{
+		Name: gocloak.StringP(policyName),
+		Type: gocloak.StringP("client"),
+		ClientPolicyRepresentation: gocloak.ClientPolicyRepresentation{
+			Clients: []string{
+				gocloakClientID,
+			},
+		},
+	})
+	defer tearDownPolicy()
+
+	// Create Resource
+	tearDownResource, resourceID := CreateResource(t, client, gocloakClientID)
+	defer tearDownResource()
+
+	// Create Permission
+	permissionName := GetRandomName("PermissionName")
+	tearDownPermission, permissionID := CreatePermission(t, client, gocloakClientID, gocloak.PermissionRepresentation{
+		Name:      gocloak.StringP(permissionName),
+		Type:      gocloak.StringP("resource"),
+		Policies:  []string{policyID},
+		Resources: []string{resourceID},
 	})
+	defer tearDownPermission()
 
-	func() {
-		permission()
-		resource()
-		policy()
-	}()
+	permissions, err := client.GetResourcePermissions(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		resourceID,
+	)
+	require.NoError(t, err, "GetResourcePermissions failed")
+	require.Len(t, permissions, 1)
+	require.Equal(t, permissionID, gocloak.PString(permissions[0].ID))
 
-	// List Polices
-	_, err := client.GetAuthorizationPolicyAssociatedPolicies(
+	attributes, err := client.GetResourceAttributes(
 		context.Background(),
 		token.AccessToken,
 		cfg.GoCloak.Realm,
 		gocloakClientID,
-		permissionID,
+		resourceID,
 	)
-	require.Error(t, err, "GetAuthorizationPolicyAssociatedPolicies no error")
+	require.NoError(t, err, "GetResourceAttributes failed")
+	require.Equal(t, []string{"baz"}, attributes["bar"])
+
+	permission, err := client.SearchPermission(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		permissionName,
+	)
+	require.NoError(t, err, "SearchPermission failed")
+	require.NotNil(t, permission)
+	require.Equal(t, permissionID, gocloak.PString(permission.ID))
+
+	policy, err := client.SearchPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		policyName,
+	)
+	require.NoError(t, err, "SearchPolicy failed")
+	require.NotNil(t, policy)
+	require.Equal(t, policyID, gocloak.PString(policy.ID))
+
+	policy, err = client.SearchPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		GetRandomName("PolicyName"),
+	)
+	require.NoError(t, err, "SearchPolicy failed")
+	require.Nil(t, policy)
 }
 
 func Test_GetAuthorizationPolicyAssociatedPolicies(t *testing.T) {
@@ -6123,6 +7213,79 @@ func Test_UserPolicy(t *testing.T) {
 	defer tearDown()
 }
 
+func Test_EvaluatePolicies(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	tearDownResource, resourceID := CreateResource(t, client, gocloakClientID)
+	defer tearDownResource()
+
+	tearDownAllowedUser, allowedUserID := CreateUser(t, client)
+	defer tearDownAllowedUser()
+	tearDownDeniedUser, deniedUserID := CreateUser(t, client)
+	defer tearDownDeniedUser()
+
+	tearDownPolicy, policyID := CreatePolicy(t, client, gocloakClientID, gocloak.PolicyRepresentation{
+		Name:        GetRandomNameP("PolicyName"),
+		Description: gocloak.StringP("User Policy"),
+		Type:        gocloak.StringP("user"),
+		UserPolicyRepresentation: gocloak.UserPolicyRepresentation{
+			Users: []string{allowedUserID},
+		},
+	})
+	defer tearDownPolicy()
+
+	tearDownPermission, permissionID := CreatePermission(t, client, gocloakClientID, gocloak.PermissionRepresentation{
+		Name:      GetRandomNameP("PermissionName"),
+		Type:      gocloak.StringP("resource"),
+		Policies:  []string{policyID},
+		Resources: []string{resourceID},
+	})
+	defer tearDownPermission()
+
+	evaluate := func(userID string) *gocloak.EvaluationResultRepresentation {
+		response, err := client.EvaluatePolicies(
+			context.Background(),
+			token.AccessToken,
+			cfg.GoCloak.Realm,
+			gocloakClientID,
+			gocloak.PolicyEvaluationRequest{
+				UserID:    gocloak.StringP(userID),
+				Resources: []gocloak.ResourceRepresentation{{ID: gocloak.StringP(resourceID)}},
+				Context: map[string]map[string]string{
+					"attributes": {"kc.client.network.ip_address": "127.0.0.1"},
+				},
+			})
+		require.NoError(t, err, "EvaluatePolicies failed")
+		for _, result := range response.Results {
+			if gocloak.PString(result.Resource.ID) == resourceID {
+				return result
+			}
+		}
+		require.Fail(t, "evaluation result of the resource missing")
+		return nil
+	}
+
+	allowed := evaluate(allowedUserID)
+	require.Equal(t, gocloak.Permit, *allowed.Status)
+	require.Len(t, allowed.Policies, 1)
+	require.Equal(t, permissionID, gocloak.PString(allowed.Policies[0].Policy.ID))
+	require.Equal(t, gocloak.Permit, *allowed.Policies[0].Status)
+
+	denied := evaluate(deniedUserID)
+	require.Equal(t, gocloak.Deny, *denied.Status)
+
+	_, err := client.EvaluatePolicies(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		gocloak.PolicyEvaluationRequest{})
+	require.Error(t, err)
+}
+
 func Test_AggregatedPolicy(t *testing.T) {
 	t.Parallel()
 	client := NewClientWithDebug(t)
@@ -6193,6 +7356,180 @@ func Test_GroupPolicy(t *testing.T) {
 	defer tearDown()
 }
 
+func Test_RegexPolicy(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	// Create
+	tearDown, policyID := CreatePolicy(t, client, gocloakClientID, gocloak.PolicyRepresentation{
+		Name:        GetRandomNameP("PolicyName"),
+		Description: gocloak.StringP("Regex Policy"),
+		Type:        gocloak.StringP("regex"),
+		RegexPolicyRepresentation: gocloak.RegexPolicyRepresentation{
+			TargetClaim: gocloak.StringP("email"),
+			Pattern:     gocloak.StringP(".*@example\\.com$"),
+		},
+	})
+	// Delete
+	defer tearDown()
+
+	policy, err := client.GetTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		"regex",
+		policyID)
+	require.NoError(t, err, "GetTypedPolicy failed")
+	regexPolicy, ok := policy.(*gocloak.RegexPolicy)
+	require.True(t, ok)
+	require.Equal(t, "email", gocloak.PString(regexPolicy.TargetClaim))
+
+	regexPolicy.Pattern = gocloak.StringP(".*@example\\.org$")
+	err = client.UpdateTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		regexPolicy)
+	require.NoError(t, err, "UpdateTypedPolicy failed")
+
+	policy, err = client.GetTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		"regex",
+		policyID)
+	require.NoError(t, err, "GetTypedPolicy failed")
+	require.Equal(t, ".*@example\\.org$", gocloak.PString(policy.(*gocloak.RegexPolicy).Pattern))
+}
+
+func Test_ClientScopePolicy(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	tearDownScope, scopeID := CreateClientScope(t, client, nil)
+	defer tearDownScope()
+
+	// Create
+	tearDown, policyID := CreatePolicy(t, client, gocloakClientID, gocloak.PolicyRepresentation{
+		Name:        GetRandomNameP("PolicyName"),
+		Description: gocloak.StringP("Client Scope Policy"),
+		Type:        gocloak.StringP("client-scope"),
+		ClientScopePolicyRepresentation: gocloak.ClientScopePolicyRepresentation{
+			ClientScopes: []gocloak.ClientScopeDefinition{
+				{
+					ID:       gocloak.StringP(scopeID),
+					Required: gocloak.BoolP(true),
+				},
+			},
+		},
+	})
+	// Delete
+	defer tearDown()
+
+	policy, err := client.GetTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		"client-scope",
+		policyID)
+	require.NoError(t, err, "GetTypedPolicy failed")
+	clientScopePolicy, ok := policy.(*gocloak.ClientScopePolicy)
+	require.True(t, ok)
+	require.Len(t, clientScopePolicy.ClientScopes, 1)
+	require.Equal(t, scopeID, gocloak.PString(clientScopePolicy.ClientScopes[0].ID))
+	require.True(t, gocloak.PBool(clientScopePolicy.ClientScopes[0].Required))
+}
+
+func Test_CreateGetUpdateTypedPolicy(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+
+	tearDownGroup, groupID := CreateGroup(t, client)
+	defer tearDownGroup()
+
+	created, err := client.CreateTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		&gocloak.GroupPolicy{
+			TypedPolicyBase: gocloak.TypedPolicyBase{
+				Name:        GetRandomNameP("PolicyName"),
+				Description: gocloak.StringP("Typed Group Policy"),
+			},
+			GroupPolicyRepresentation: gocloak.GroupPolicyRepresentation{
+				Groups: []gocloak.GroupDefinition{
+					{
+						ID:             gocloak.StringP(groupID),
+						ExtendChildren: gocloak.BoolP(true),
+					},
+				},
+			},
+		})
+	require.NoError(t, err, "CreateTypedPolicy failed")
+	require.IsType(t, &gocloak.GroupPolicy{}, created)
+	policyID := gocloak.PString(created.Base().ID)
+	defer func() {
+		err := client.DeletePolicy(
+			context.Background(),
+			token.AccessToken,
+			cfg.GoCloak.Realm,
+			gocloakClientID,
+			policyID)
+		require.NoError(t, err, "DeletePolicy failed")
+	}()
+
+	policy, err := client.GetTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		"group",
+		policyID)
+	require.NoError(t, err, "GetTypedPolicy failed")
+	groupPolicy, ok := policy.(*gocloak.GroupPolicy)
+	require.True(t, ok)
+	require.Len(t, groupPolicy.Groups, 1)
+	require.Equal(t, groupID, gocloak.PString(groupPolicy.Groups[0].ID))
+	require.True(t, gocloak.PBool(groupPolicy.Groups[0].ExtendChildren))
+
+	groupPolicy.Description = gocloak.StringP("Updated Typed Group Policy")
+	err = client.UpdateTypedPolicy(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		groupPolicy)
+	require.NoError(t, err, "UpdateTypedPolicy failed")
+
+	policies, err := client.GetTypedPolicies(
+		context.Background(),
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloakClientID,
+		gocloak.GetPolicyParams{
+			Name:       created.Base().Name,
+			Permission: gocloak.BoolP(false),
+		})
+	require.NoError(t, err, "GetTypedPolicies failed")
+	require.Len(t, policies, 1)
+	groupPolicy, ok = policies[0].(*gocloak.GroupPolicy)
+	require.True(t, ok)
+	require.Equal(t, "Updated Typed Group Policy", gocloak.PString(groupPolicy.Description))
+	require.Len(t, groupPolicy.Groups, 1)
+	require.Equal(t, groupID, gocloak.PString(groupPolicy.Groups[0].ID))
+}
+
 func Test_ErrorsGrantGetUpdateDeleteUserPermission(t *testing.T) {
 	t.Parallel()
 	cfg := GetConfig(t)
@@ -7039,6 +8376,63 @@ func TestGocloak_CreateAuthenticationFlowsAndCreateAuthenticationExecutionAndFlo
 	require.NoError(t, err, "Failed to delete authentication flow")
 }
 
+func TestGocloak_CopyAuthenticationFlowAndOrderExecutions(t *testing.T) {
+	t.Parallel()
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	tearDown, realm := CreateRealm(t, client)
+	defer tearDown()
+
+	flowAlias := GetRandomName("browser-copy")
+	err := client.CopyAuthenticationFlow(ctx, token.AccessToken, realm, "browser", flowAlias)
+	require.NoError(t, err, "Failed to copy authentication flow")
+
+	topLevelExecutions := func() []string {
+		executions, err := client.GetAuthenticationExecutions(ctx, token.AccessToken, realm, flowAlias)
+		require.NoError(t, err, "Failed to get authentication executions")
+		var ids []string
+		for _, execution := range executions {
+			if gocloak.PInt(execution.Level) == 0 {
+				ids = append(ids, gocloak.PString(execution.ID))
+			}
+		}
+		return ids
+	}
+
+	executionIDs := topLevelExecutions()
+	require.Greater(t, len(executionIDs), 2, "copied flow should contain the executions of the browser flow")
+
+	err = client.LowerAuthenticationExecutionPriority(ctx, token.AccessToken, realm, executionIDs[0])
+	require.NoError(t, err, "Failed to lower authentication execution priority")
+	require.Equal(t, executionIDs[0], topLevelExecutions()[1])
+
+	err = client.RaiseAuthenticationExecutionPriority(ctx, token.AccessToken, realm, executionIDs[0])
+	require.NoError(t, err, "Failed to raise authentication execution priority")
+	require.Equal(t, executionIDs, topLevelExecutions())
+
+	slices.Reverse(executionIDs)
+	err = client.OrderAuthenticationExecutions(ctx, token.AccessToken, realm, flowAlias, executionIDs)
+	require.NoError(t, err, "Failed to order authentication executions")
+	require.Equal(t, executionIDs, topLevelExecutions())
+
+	err = client.OrderAuthenticationExecutions(ctx, token.AccessToken, realm, flowAlias, executionIDs[1:])
+	require.Error(t, err, "ordering a subset of the executions should fail")
+
+	for name, getProviders := range map[string]func(context.Context, string, string) ([]*gocloak.AuthenticatorProviderRepresentation, error){
+		"authenticator":        client.GetAuthenticatorProviders,
+		"form":                 client.GetFormProviders,
+		"form action":          client.GetFormActionProviders,
+		"client authenticator": client.GetClientAuthenticatorProviders,
+	} {
+		providers, err := getProviders(ctx, token.AccessToken, realm)
+		require.NoError(t, err, "Failed to get %s providers", name)
+		require.NotEmpty(t, providers, "no %s providers returned", name)
+		require.NotEmpty(t, gocloak.PString(providers[0].ID))
+	}
+}
+
 func TestGocloak_AuthenticatorConfigCRUD(t *testing.T) {
 	t.Parallel()
 	cfg := GetConfig(t)
@@ -7284,6 +8678,69 @@ func TestGocloak_UpdateRequiredAction(t *testing.T) {
 	require.NoError(t, err, "Failed to update required action")
 }
 
+func TestGocloak_RequiredActionConfigAndOrder(t *testing.T) {
+	t.Parallel()
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	tearDown, realm := CreateRealm(t, client)
+	defer tearDown()
+
+	description, err := client.GetRequiredActionConfigDescription(ctx, token.AccessToken, realm, "UPDATE_PASSWORD")
+	require.NoError(t, err, "Failed to get required action config description")
+	var properties []string
+	for _, property := range description.Properties {
+		properties = append(properties, gocloak.PString(property.Name))
+	}
+	require.Contains(t, properties, "max_auth_age")
+
+	err = client.UpdateRequiredActionConfig(ctx, token.AccessToken, realm, "UPDATE_PASSWORD",
+		gocloak.RequiredActionConfigRepresentation{
+			Config: map[string]string{"max_auth_age": "600"},
+		})
+	require.NoError(t, err, "Failed to update required action config")
+
+	config, err := client.GetRequiredActionConfig(ctx, token.AccessToken, realm, "UPDATE_PASSWORD")
+	require.NoError(t, err, "Failed to get required action config")
+	require.Equal(t, "600", config.Config["max_auth_age"])
+
+	err = client.DeleteRequiredActionConfig(ctx, token.AccessToken, realm, "UPDATE_PASSWORD")
+	require.NoError(t, err, "Failed to delete required action config")
+
+	aliases := func() []string {
+		requiredActions, err := client.GetRequiredActions(ctx, token.AccessToken, realm)
+		require.NoError(t, err, "Failed to get required actions")
+		var result []string
+		for _, requiredAction := range requiredActions {
+			result = append(result, gocloak.PString(requiredAction.Alias))
+		}
+		return result
+	}
+
+	before := aliases()
+	require.Greater(t, len(before), 3)
+
+	err = client.LowerRequiredActionPriority(ctx, token.AccessToken, realm, before[0])
+	require.NoError(t, err, "Failed to lower required action priority")
+	require.Equal(t, before[0], aliases()[1])
+
+	err = client.RaiseRequiredActionPriority(ctx, token.AccessToken, realm, before[0])
+	require.NoError(t, err, "Failed to raise required action priority")
+	require.Equal(t, before, aliases())
+
+	err = client.OrderRequiredActions(ctx, token.AccessToken, realm, []string{before[3], before[1]})
+	require.NoError(t, err, "Failed to order required actions")
+	expected := append([]string{before[3], before[1], before[0], before[2]}, before[4:]...)
+	require.Equal(t, expected, aliases())
+
+	err = client.OrderRequiredActions(ctx, token.AccessToken, realm, []string{"unknown"})
+	require.Error(t, err)
+
+	_, err = client.GetRequiredActionConfig(ctx, token.AccessToken, realm, "")
+	require.Error(t, err)
+}
+
 func CreateComponent(t *testing.T, client gocloak.GoCloakIface) (func(), *gocloak.Component) {
 	newComponent := &gocloak.Component{
 		Name:         GetRandomNameP("CreateComponent"),
@@ -7847,3 +9304,75 @@ func Test_GetMemberAssociatedOrganizations(t *testing.T) {
 
 	require.GreaterOrEqual(t, len(organizations), 1)
 }
+
+func Test_RegisterGetUpdateDeleteRegisteredClient(t *testing.T) {
+	t.Parallel()
+	cfg := GetConfig(t)
+	client := NewClientWithDebug(t)
+	token := GetAdminToken(t, client)
+	ctx := context.Background()
+
+	initialAccess, err := client.CreateClientInitialAccess(
+		ctx,
+		token.AccessToken,
+		cfg.GoCloak.Realm,
+		gocloak.ClientInitialAccessCreatePresentation{
+			Expiration: gocloak.Int32P(300),
+			Count:      gocloak.Int32P(1),
+		})
+	require.NoError(t, err, "CreateClientInitialAccess failed")
+	require.NotNil(t, initialAccess.Token)
+	defer func() {
+		err := client.DeleteClientInitialAccess(
+			ctx,
+			token.AccessToken,
+			cfg.GoCloak.Realm,
+			*initialAccess.ID)
+		// the token is removed by Keycloak once its count is exhausted
+		if apiErr, ok := err.(*gocloak.APIError); !ok || apiErr.Code != http.StatusNotFound {
+			require.NoError(t, err, "DeleteClientInitialAccess failed")
+		}
+	}()
+
+	registered, err := client.RegisterClient(
+		ctx,
+		*initialAccess.Token,
+		cfg.GoCloak.Realm,
+		gocloak.OIDCClientRepresentation{
+			ClientName:   GetRandomNameP("RegisteredClient"),
+			RedirectURIs: []string{"http://localhost/callback"},
+			GrantTypes:   []string{"authorization_code"},
+		})
+	require.NoError(t, err, "RegisterClient failed")
+	require.NotNil(t, registered.ClientID)
+	require.NotNil(t, registered.RegistrationAccessToken)
+
+	fetched, err := client.GetRegisteredClient(
+		ctx,
+		*registered.RegistrationAccessToken,
+		cfg.GoCloak.Realm,
+		*registered.ClientID)
+	require.NoError(t, err, "GetRegisteredClient failed")
+	require.Equal(t, *registered.ClientID, gocloak.PString(fetched.ClientID))
+
+	fetched.RedirectURIs = []string{"http://localhost/callback", "http://localhost/other"}
+	registrationAccessToken := gocloak.PString(fetched.RegistrationAccessToken)
+	if registrationAccessToken == "" {
+		registrationAccessToken = *registered.RegistrationAccessToken
+	}
+	updated, err := client.UpdateRegisteredClient(
+		ctx,
+		registrationAccessToken,
+		cfg.GoCloak.Realm,
+		*fetched)
+	require.NoError(t, err, "UpdateRegisteredClient failed")
+	require.Len(t, updated.RedirectURIs, 2)
+	require.NotNil(t, updated.RegistrationAccessToken)
+
+	err = client.DeleteRegisteredClient(
+		ctx,
+		*updated.RegistrationAccessToken,
+		cfg.GoCloak.Realm,
+		*registered.ClientID)
+	require.NoError(t, err, "DeleteRegisteredClient failed")
+}
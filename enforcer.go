@@ -0,0 +1,267 @@
+package gocloak
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ScopesEnforcementMode is an enum type for the scopes-enforcement-mode of a PolicyEnforcerMethodConfig
+type ScopesEnforcementMode string
+
+// ScopesEnforcementMode values
+const (
+	ScopesEnforcementAll      ScopesEnforcementMode = "ALL"
+	ScopesEnforcementAny      ScopesEnforcementMode = "ANY"
+	ScopesEnforcementDisabled ScopesEnforcementMode = "DISABLED"
+)
+
+// PolicyEnforcerConfig configures a PolicyEnforcer. It uses the same JSON format as the policy-enforcer
+// configuration of the Keycloak Java adapters, so existing configurations can be unmarshalled into it.
+type PolicyEnforcerConfig struct {
+	// EnforcementMode of the enforcer, defaults to ENFORCING which denies requests to paths without configuration.
+	// PERMISSIVE allows them and DISABLED allows all requests.
+	EnforcementMode PolicyEnforcementMode `json:"enforcement-mode,omitempty"`
+	// Paths which are protected by the enforcer
+	Paths []PolicyEnforcerPathConfig `json:"paths,omitempty"`
+	// HTTPMethodAsScope uses the HTTP method as scope for paths without method configuration
+	HTTPMethodAsScope bool `json:"http-method-as-scope,omitempty"`
+}
+
+// PolicyEnforcerPathConfig maps a path to a resource of the resource server
+type PolicyEnforcerPathConfig struct {
+	// Name of the resource. If empty, the resource is matched by the URI of the request instead.
+	Name string `json:"name,omitempty"`
+	// Path pattern, e.g. "/api/items/{id}", "/api/*" or "/*.html"
+	Path string `json:"path,omitempty"`
+	// Methods with the scopes required to access the resource with them
+	Methods []PolicyEnforcerMethodConfig `json:"methods,omitempty"`
+	// Scopes required for methods without method configuration
+	Scopes []string `json:"scopes,omitempty"`
+	// EnforcementMode of the path, DISABLED allows all requests to it
+	EnforcementMode PolicyEnforcementMode `json:"enforcement-mode,omitempty"`
+}
+
+// PolicyEnforcerMethodConfig holds the scopes required to access a resource with an HTTP method
+type PolicyEnforcerMethodConfig struct {
+	Method string   `json:"method"`
+	Scopes []string `json:"scopes,omitempty"`
+	// ScopesEnforcementMode defaults to ALL, ANY requires only one of the scopes, DISABLED allows all requests
+	ScopesEnforcementMode ScopesEnforcementMode `json:"scopes-enforcement-mode,omitempty"`
+}
+
+// PolicyEnforcer is a policy enforcement point for a resource server. It maps requests to the resources and scopes
+// of its configuration and asks Keycloak whether the bearer token of the request grants access to them.
+type PolicyEnforcer struct {
+	client   GoCloakIface
+	realm    string
+	clientID string
+	config   PolicyEnforcerConfig
+	rptCache *RPTCache
+}
+
+// NewPolicyEnforcer returns a PolicyEnforcer for the resource server with the given client id
+func NewPolicyEnforcer(client GoCloakIface, realm, clientID string, config PolicyEnforcerConfig) *PolicyEnforcer {
+	return &PolicyEnforcer{
+		client:   client,
+		realm:    realm,
+		clientID: clientID,
+		config:   config,
+	}
+}
+
+// SetRPTCache makes the enforcer check the permissions of paths with a resource name against cached RPTs
+// instead of asking Keycloak for a decision on every request
+func (e *PolicyEnforcer) SetRPTCache(cache *RPTCache) {
+	e.rptCache = cache
+}
+
+// Enforce returns whether the access token grants access to the given method and path of the resource server.
+// A denied permission is not an error, an error is only returned if Keycloak could not be asked.
+func (e *PolicyEnforcer) Enforce(ctx context.Context, accessToken, method, path string) (bool, error) {
+	if e.config.EnforcementMode == Disabled {
+		return true, nil
+	}
+
+	pathConfig := e.matchPath(path)
+	if pathConfig == nil {
+		return e.config.EnforcementMode == Permissive, nil
+	}
+	if pathConfig.EnforcementMode == Disabled {
+		return true, nil
+	}
+
+	scopes, mode := e.requiredScopes(pathConfig, method)
+	if mode == ScopesEnforcementDisabled {
+		return true, nil
+	}
+
+	resource := pathConfig.Name
+	options := RequestingPartyTokenOptions{
+		Audience: StringP(e.clientID),
+	}
+	if resource == "" {
+		resource = path
+		options.PermissionResourceFormat = StringP("uri")
+		options.PermissionResourceMatchingURI = BoolP(true)
+	}
+	permission := resource
+	if len(scopes) > 0 {
+		permission += "#" + strings.Join(scopes, ",")
+	}
+	options.Permissions = []string{permission}
+
+	if e.rptCache != nil && pathConfig.Name != "" {
+		permissions, err := e.rptCache.GetRequestingPartyPermissions(ctx, accessToken, options)
+		if err != nil {
+			return accessDenied(err)
+		}
+		return grantsPermission(permissions, pathConfig.Name, scopes, mode), nil
+	}
+
+	// Keycloak grants a permission if one of its scopes is granted, all scopes have to be checked locally
+	if mode == ScopesEnforcementAll && len(scopes) > 1 {
+		permissions, err := e.client.GetRequestingPartyPermissions(ctx, accessToken, e.realm, options)
+		if err != nil {
+			return accessDenied(err)
+		}
+		return grantsPermission(*permissions, pathConfig.Name, scopes, mode), nil
+	}
+
+	decision, err := e.client.GetRequestingPartyPermissionDecision(ctx, accessToken, e.realm, options)
+	if err != nil {
+		return accessDenied(err)
+	}
+	return PBool(decision.Result), nil
+}
+
+// Handler returns a middleware which enforces the policies for all requests to next.
+// Requests without bearer token are rejected with 401, denied requests with 403.
+func (e *PolicyEnforcer) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok && e.config.EnforcementMode != Disabled {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := e.Enforce(r.Context(), accessToken, r.Method, r.URL.Path)
+		var apiErr *APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.Code == http.StatusUnauthorized:
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		case err != nil:
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		case !allowed:
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// grantsPermission returns whether the permissions grant the scopes of the resource with the given name.
+// If the name is empty, the permissions of any resource are checked.
+func grantsPermission(permissions []RequestingPartyPermission, name string, scopes []string, mode ScopesEnforcementMode) bool {
+	for _, permission := range permissions {
+		if name != "" && PString(permission.ResourceName) != name {
+			continue
+		}
+		granted := func(scope string) bool { return slices.Contains(permission.Scopes, scope) }
+		switch {
+		case len(scopes) == 0,
+			mode == ScopesEnforcementAny && slices.ContainsFunc(scopes, granted),
+			mode == ScopesEnforcementAll && !slices.ContainsFunc(scopes, func(scope string) bool { return !granted(scope) }):
+			return true
+		}
+	}
+	return false
+}
+
+// accessDenied maps the access_denied error returned by Keycloak for denied permissions to a denied decision
+func accessDenied(err error) (bool, error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		return false, nil
+	}
+	return false, err
+}
+
+// requiredScopes returns the scopes required to access a path with the given method
+func (e *PolicyEnforcer) requiredScopes(pathConfig *PolicyEnforcerPathConfig, method string) ([]string, ScopesEnforcementMode) {
+	for _, methodConfig := range pathConfig.Methods {
+		if strings.EqualFold(methodConfig.Method, method) {
+			mode := methodConfig.ScopesEnforcementMode
+			if mode == "" {
+				mode = ScopesEnforcementAll
+			}
+			return methodConfig.Scopes, mode
+		}
+	}
+
+	if len(pathConfig.Scopes) == 0 && e.config.HTTPMethodAsScope {
+		return []string{strings.ToUpper(method)}, ScopesEnforcementAll
+	}
+	return pathConfig.Scopes, ScopesEnforcementAll
+}
+
+// matchPath returns the most specific path configuration matching the path, or nil if there is none
+func (e *PolicyEnforcer) matchPath(path string) *PolicyEnforcerPathConfig {
+	var result *PolicyEnforcerPathConfig
+	best := -1
+	for i := range e.config.Paths {
+		if score := matchPolicyEnforcerPath(e.config.Paths[i].Path, path); score > best {
+			result = &e.config.Paths[i]
+			best = score
+		}
+	}
+	return result
+}
+
+// matchPolicyEnforcerPath returns how specific the pattern matches the path, or -1 if it does not match.
+// Patterns are matched segment by segment: "{name}" matches any segment and a trailing "*" any number of
+// remaining segments. A trailing "*.ext" matches the remaining segments if the last one has the extension,
+// e.g. "/*.html" matches "/a/b.html". Literal segments are more specific than templates, which are more
+// specific than wildcards, and an identical pattern is the most specific match.
+func matchPolicyEnforcerPath(pattern, path string) int {
+	if pattern == path {
+		return 1 << 30
+	}
+
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	score := 0
+	for i, segment := range patternSegments {
+		if segment == "*" && i == len(patternSegments)-1 {
+			return score
+		}
+		if i >= len(pathSegments) {
+			return -1
+		}
+		if strings.HasPrefix(segment, "*.") && i == len(patternSegments)-1 {
+			if !strings.HasSuffix(pathSegments[len(pathSegments)-1], segment[1:]) {
+				return -1
+			}
+			return score + 1
+		}
+
+		switch {
+		case segment == pathSegments[i]:
+			score += 3
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && pathSegments[i] != "":
+			score += 2
+		case strings.HasPrefix(segment, "*.") && strings.HasSuffix(pathSegments[i], segment[1:]):
+			score++
+		default:
+			return -1
+		}
+	}
+
+	if len(patternSegments) != len(pathSegments) {
+		return -1
+	}
+	return score
+}
@@ -0,0 +1,132 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+type enforcerTestClient struct {
+	gocloak.GoCloakIface
+	granted     []string
+	permissions []gocloak.RequestingPartyPermission
+	requested   []string
+}
+
+func (c *enforcerTestClient) GetRequestingPartyPermissionDecision(_ context.Context, token, _ string, options gocloak.RequestingPartyTokenOptions) (*gocloak.RequestingPartyPermissionDecision, error) {
+	if token == "invalid" {
+		return nil, &gocloak.APIError{Code: http.StatusUnauthorized, Message: "401 Unauthorized"}
+	}
+	c.requested = append(c.requested, options.Permissions...)
+	if !slices.Contains(c.granted, options.Permissions[0]) {
+		return nil, &gocloak.APIError{Code: http.StatusForbidden, Message: "403 Forbidden: access_denied"}
+	}
+	return &gocloak.RequestingPartyPermissionDecision{Result: gocloak.BoolP(true)}, nil
+}
+
+func (c *enforcerTestClient) GetRequestingPartyPermissions(_ context.Context, _, _ string, options gocloak.RequestingPartyTokenOptions) (*[]gocloak.RequestingPartyPermission, error) {
+	c.requested = append(c.requested, options.Permissions...)
+	return &c.permissions, nil
+}
+
+func newTestPolicyEnforcer(t *testing.T, client gocloak.GoCloakIface) *gocloak.PolicyEnforcer {
+	var config gocloak.PolicyEnforcerConfig
+	err := json.Unmarshal([]byte(`{
+		"paths": [
+			{"name": "items", "path": "/api/items/*", "methods": [
+				{"method": "GET", "scopes": ["view"]},
+				{"method": "PUT", "scopes": ["view", "edit"]},
+				{"method": "PATCH", "scopes": ["view", "edit"], "scopes-enforcement-mode": "ANY"}
+			]},
+			{"name": "item", "path": "/api/items/{id}/details", "scopes": ["details"]},
+			{"path": "/api/documents/{id}"},
+			{"path": "/public/*", "enforcement-mode": "DISABLED"},
+			{"name": "pages", "path": "/*.html"},
+			{"name": "reports", "path": "/reports/*.pdf"}
+		]
+	}`), &config)
+	require.NoError(t, err)
+	return gocloak.NewPolicyEnforcer(client, "realm", "resource-server", config)
+}
+
+func TestPolicyEnforcer(t *testing.T) {
+	client := &enforcerTestClient{
+		granted: []string{"items#view", "items#view,edit", "item#details", "pages", "reports"},
+		permissions: []gocloak.RequestingPartyPermission{
+			{ResourceName: gocloak.StringP("items"), Scopes: []string{"view"}},
+		},
+	}
+	enforcer := newTestPolicyEnforcer(t, client)
+
+	testCases := []struct {
+		method    string
+		path      string
+		allowed   bool
+		requested []string
+	}{
+		{method: http.MethodGet, path: "/api/items/1", allowed: true, requested: []string{"items#view"}},
+		{method: http.MethodPut, path: "/api/items/1", allowed: false, requested: []string{"items#view,edit"}},
+		{method: http.MethodPatch, path: "/api/items/1", allowed: true, requested: []string{"items#view,edit"}},
+		{method: http.MethodDelete, path: "/api/items/1", allowed: false, requested: []string{"items"}},
+		{method: http.MethodGet, path: "/api/items/1/details", allowed: true, requested: []string{"item#details"}},
+		{method: http.MethodGet, path: "/api/documents/1", allowed: false, requested: []string{"/api/documents/1"}},
+		{method: http.MethodGet, path: "/public/index.html", allowed: true},
+		{method: http.MethodGet, path: "/index.html", allowed: true, requested: []string{"pages"}},
+		{method: http.MethodGet, path: "/docs/guide/index.html", allowed: true, requested: []string{"pages"}},
+		{method: http.MethodGet, path: "/reports/2024/q1.pdf", allowed: true, requested: []string{"reports"}},
+		{method: http.MethodGet, path: "/reports/2024/q1.csv", allowed: false},
+		{method: http.MethodGet, path: "/docs/guide.pdf", allowed: false},
+		{method: http.MethodGet, path: "/other", allowed: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.method+" "+testCase.path, func(t *testing.T) {
+			client.requested = nil
+			allowed, err := enforcer.Enforce(context.Background(), "token", testCase.method, testCase.path)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.allowed, allowed)
+			assert.Equal(t, testCase.requested, client.requested)
+		})
+	}
+
+	client.permissions[0].Scopes = []string{"view", "edit"}
+	allowed, err := enforcer.Enforce(context.Background(), "token", http.MethodPut, "/api/items/1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestPolicyEnforcerHandler(t *testing.T) {
+	client := &enforcerTestClient{granted: []string{"items#view"}}
+	handler := newTestPolicyEnforcer(t, client).Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	testCases := []struct {
+		method        string
+		authorization string
+		status        int
+	}{
+		{method: http.MethodGet, authorization: "Bearer token", status: http.StatusNoContent},
+		{method: http.MethodDelete, authorization: "Bearer token", status: http.StatusForbidden},
+		{method: http.MethodGet, authorization: "Bearer invalid", status: http.StatusUnauthorized},
+		{method: http.MethodGet, status: http.StatusUnauthorized},
+	}
+
+	for _, testCase := range testCases {
+		request := httptest.NewRequest(testCase.method, "/api/items/1", nil)
+		if testCase.authorization != "" {
+			request.Header.Set("Authorization", testCase.authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, testCase.status, recorder.Code, testCase.method+" "+testCase.authorization)
+	}
+}
@@ -0,0 +1,409 @@
+package gocloak_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+// eventsTestResponse is the response of eventsTestServer to a single request
+type eventsTestResponse struct {
+	events []*gocloak.EventRepresentation
+	status int
+}
+
+// eventsTestServer answers the requests for events and admin events with the given responses in order,
+// and with no events once they are used up
+type eventsTestServer struct {
+	mu        sync.Mutex
+	responses []eventsTestResponse
+	requests  []*http.Request
+	times     []time.Time
+}
+
+func (s *eventsTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	s.times = append(s.times, time.Now())
+	response := eventsTestResponse{events: []*gocloak.EventRepresentation{}}
+	if len(s.responses) > 0 {
+		response = s.responses[0]
+		s.responses = s.responses[1:]
+	}
+	s.mu.Unlock()
+
+	if response.status != 0 {
+		w.WriteHeader(response.status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response.events)
+}
+
+// queries returns the given query parameter of all requests received so far
+func (s *eventsTestServer) queries(name string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []string
+	for _, request := range s.requests {
+		result = append(result, request.URL.Query().Get(name))
+	}
+	return result
+}
+
+func newEventsTestServer(t *testing.T, responses ...eventsTestResponse) (*eventsTestServer, *gocloak.GoCloak) {
+	handler := &eventsTestServer{responses: responses}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return handler, gocloak.NewClient(server.URL)
+}
+
+func testEvent(id string, time int64) *gocloak.EventRepresentation {
+	return &gocloak.EventRepresentation{ID: gocloak.StringP(id), Time: time}
+}
+
+func eventIDs(events []*gocloak.TailedEvent) []string {
+	var result []string
+	for _, event := range events {
+		result = append(result, gocloak.PString(event.Event.ID))
+	}
+	return result
+}
+
+// receiveEvents receives count events and fails if more events follow
+func receiveEvents[T any](t *testing.T, events <-chan T, count int) []T {
+	var result []T
+	for len(result) < count {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "the tail stopped after %d events", len(result))
+			result = append(result, event)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout", "received %d of %d events", len(result), count)
+		}
+	}
+
+	select {
+	case event, ok := <-events:
+		assert.False(t, ok, "unexpected event %v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+	return result
+}
+
+func TestTailEventsInvalidParams(t *testing.T) {
+	_, client := newEventsTestServer(t)
+
+	testCases := []struct {
+		name   string
+		events func(options gocloak.TailEventsOptions) int
+	}{
+		{
+			name: "events with type",
+			events: func(options gocloak.TailEventsOptions) int {
+				return drainTail(client.TailEvents(context.Background(), gocloak.StaticToken("token"), "realm", gocloak.GetEventsParams{Type: []string{"LOGIN"}}, options))
+			},
+		},
+		{
+			name: "events with dateTo",
+			events: func(options gocloak.TailEventsOptions) int {
+				return drainTail(client.TailEvents(context.Background(), gocloak.StaticToken("token"), "realm", gocloak.GetEventsParams{DateTo: gocloak.StringP("2024-01-01")}, options))
+			},
+		},
+		{
+			name: "admin events with operation types",
+			events: func(options gocloak.TailEventsOptions) int {
+				return drainTail(client.TailAdminEvents(context.Background(), gocloak.StaticToken("token"), "realm", gocloak.GetAdminEventsParams{OperationTypes: []string{"CREATE"}}, options))
+			},
+		},
+		{
+			name: "admin events with first",
+			events: func(options gocloak.TailEventsOptions) int {
+				return drainTail(client.TailAdminEvents(context.Background(), gocloak.StaticToken("token"), "realm", gocloak.GetAdminEventsParams{First: gocloak.Int32P(10)}, options))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var errs []error
+			count := testCase.events(gocloak.TailEventsOptions{OnError: func(err error) { errs = append(errs, err) }})
+			assert.Zero(t, count, "the channel has to be closed without events")
+			require.Len(t, errs, 1)
+		})
+	}
+}
+
+// drainTail returns the number of events sent to the channel until it is closed
+func drainTail[T any](events <-chan T) int {
+	count := 0
+	for range events {
+		count++
+	}
+	return count
+}
+
+func TestTailEventsPoll(t *testing.T) {
+	a := testEvent("a", 100)
+	b := testEvent("b", 100)
+	c := testEvent("c", 101)
+	d := testEvent("d", 102)
+	e := testEvent("e", 103)
+	old := testEvent("old", 99)
+
+	testCases := []struct {
+		name      string
+		cursor    gocloak.EventCursor
+		responses []eventsTestResponse
+		firsts    []string
+		expected  []string
+	}{
+		{
+			name:   "multiple pages",
+			cursor: gocloak.EventCursor{Time: 100, Hashes: []string{"a"}},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{d, c}},
+				{events: []*gocloak.EventRepresentation{b, a}},
+				{events: []*gocloak.EventRepresentation{}},
+			},
+			firsts:   []string{"0", "2", "4"},
+			expected: []string{"b", "c", "d"},
+		},
+		{
+			// e arrives after the first page was fetched and shifts c into the second page
+			name:   "pages shifting mid-poll",
+			cursor: gocloak.EventCursor{Time: 100, Hashes: []string{"a"}},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{d, c}},
+				{events: []*gocloak.EventRepresentation{c, b}},
+				{events: []*gocloak.EventRepresentation{a}},
+			},
+			firsts:   []string{"0", "2", "4"},
+			expected: []string{"b", "c", "d"},
+		},
+		{
+			name:   "duplicate events in the same millisecond",
+			cursor: gocloak.EventCursor{Time: 100, Hashes: []string{"a"}},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{e, d}},
+				{events: []*gocloak.EventRepresentation{d, b}},
+				{events: []*gocloak.EventRepresentation{b, a}},
+				{events: []*gocloak.EventRepresentation{a}},
+			},
+			firsts:   []string{"0", "2", "4", "6"},
+			expected: []string{"b", "d", "e"},
+		},
+		{
+			name:   "events of the same millisecond keep their order",
+			cursor: gocloak.EventCursor{Time: 99, Hashes: []string{"old"}},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{c, b}},
+				{events: []*gocloak.EventRepresentation{a, old}},
+				{events: []*gocloak.EventRepresentation{}},
+			},
+			firsts:   []string{"0", "2", "4"},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:   "events older than the cursor are dropped",
+			cursor: gocloak.EventCursor{Time: 101, Hashes: []string{"c"}},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{d, old}},
+			},
+			firsts:   []string{"0", "2"},
+			expected: []string{"d"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, client := newEventsTestServer(t, testCase.responses...)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			events := client.TailEvents(ctx, gocloak.StaticToken("token"), "realm", gocloak.GetEventsParams{}, gocloak.TailEventsOptions{
+				Cursor:       &testCase.cursor,
+				PageSize:     2,
+				PollInterval: time.Hour,
+			})
+
+			received := receiveEvents(t, events, len(testCase.expected))
+			assert.Equal(t, testCase.expected, eventIDs(received))
+			assert.Equal(t, testCase.firsts, server.queries("first"))
+			for _, dateFrom := range server.queries("dateFrom") {
+				assert.Equal(t, strconv.FormatInt(testCase.cursor.Time, 10), dateFrom, "dateFrom must be the time of the cursor")
+			}
+		})
+	}
+}
+
+func TestTailEventsResume(t *testing.T) {
+	a := testEvent("a", 100)
+	b := testEvent("b", 101)
+	c := testEvent("c", 101)
+
+	testCases := []struct {
+		name      string
+		cursor    *gocloak.EventCursor
+		responses []eventsTestResponse
+		dateFroms []string
+		expected  []gocloak.EventCursor
+	}{
+		{
+			name: "start after the most recent event",
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{a}},
+				{events: []*gocloak.EventRepresentation{b, a}},
+			},
+			dateFroms: []string{"", "100"},
+			expected: []gocloak.EventCursor{
+				{Time: 101, Hashes: []string{"b"}},
+			},
+		},
+		{
+			name:   "resume from cursor",
+			cursor: &gocloak.EventCursor{Time: 100, Hashes: []string{"a"}},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{c, b, a}},
+			},
+			dateFroms: []string{"100"},
+			expected: []gocloak.EventCursor{
+				{Time: 101, Hashes: []string{"b"}},
+				{Time: 101, Hashes: []string{"b", "c"}},
+			},
+		},
+		{
+			name:   "resume from empty cursor",
+			cursor: &gocloak.EventCursor{},
+			responses: []eventsTestResponse{
+				{events: []*gocloak.EventRepresentation{a}},
+			},
+			dateFroms: []string{""},
+			expected: []gocloak.EventCursor{
+				{Time: 100, Hashes: []string{"a"}},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var cursorHashes []string
+			if testCase.cursor != nil {
+				cursorHashes = append(cursorHashes, testCase.cursor.Hashes...)
+			}
+
+			server, client := newEventsTestServer(t, testCase.responses...)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			events := client.TailAdminEvents(ctx, gocloak.StaticToken("token"), "realm", gocloak.GetAdminEventsParams{}, gocloak.TailEventsOptions{
+				Cursor:       testCase.cursor,
+				PollInterval: 10 * time.Millisecond,
+			})
+
+			received := receiveEvents(t, events, len(testCase.expected))
+			var cursors []gocloak.EventCursor
+			for _, event := range received {
+				cursors = append(cursors, event.Cursor)
+			}
+			assert.Equal(t, testCase.expected, cursors)
+			cancel()
+
+			dateFroms := server.queries("dateFrom")
+			require.GreaterOrEqual(t, len(dateFroms), len(testCase.dateFroms))
+			assert.Equal(t, testCase.dateFroms, dateFroms[:len(testCase.dateFroms)])
+			if testCase.cursor != nil {
+				assert.Equal(t, cursorHashes, testCase.cursor.Hashes, "the cursor of the options must not be modified")
+			}
+		})
+	}
+}
+
+func TestTailEventsWithoutIDs(t *testing.T) {
+	a := &gocloak.EventRepresentation{Time: 100, Type: gocloak.StringP("LOGIN")}
+	b := &gocloak.EventRepresentation{Time: 100, Type: gocloak.StringP("LOGOUT")}
+
+	_, client := newEventsTestServer(t,
+		eventsTestResponse{events: []*gocloak.EventRepresentation{a}},
+		eventsTestResponse{events: []*gocloak.EventRepresentation{b, a}},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.TailEvents(ctx, gocloak.StaticToken("token"), "realm", gocloak.GetEventsParams{}, gocloak.TailEventsOptions{
+		PollInterval: 10 * time.Millisecond,
+	})
+
+	received := receiveEvents(t, events, 1)
+	assert.Equal(t, "LOGOUT", gocloak.PString(received[0].Event.Type))
+	assert.Len(t, received[0].Cursor.Hashes, 2, "events without id are identified by a hash of their content")
+}
+
+func TestTailEventsBackoff(t *testing.T) {
+	server, client := newEventsTestServer(t,
+		eventsTestResponse{status: http.StatusInternalServerError},
+		eventsTestResponse{status: http.StatusInternalServerError},
+		eventsTestResponse{status: http.StatusInternalServerError},
+		eventsTestResponse{status: http.StatusInternalServerError},
+		eventsTestResponse{events: []*gocloak.EventRepresentation{testEvent("a", 100)}},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var errs []error
+	events := client.TailEvents(ctx, gocloak.StaticToken("token"), "realm", gocloak.GetEventsParams{}, gocloak.TailEventsOptions{
+		Cursor:       &gocloak.EventCursor{},
+		PollInterval: time.Hour,
+		MinBackoff:   10 * time.Millisecond,
+		MaxBackoff:   20 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	})
+
+	received := receiveEvents(t, events, 1)
+	assert.Equal(t, []string{"a"}, eventIDs(received))
+
+	mu.Lock()
+	assert.Len(t, errs, 4)
+	mu.Unlock()
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.times, 5)
+	for i, expected := range []time.Duration{10, 20, 20, 20} {
+		assert.GreaterOrEqual(t, server.times[i+1].Sub(server.times[i]), expected*time.Millisecond, "backoff %d", i)
+	}
+}
+
+func TestTailEventsStopsWhenContextIsDone(t *testing.T) {
+	_, client := newEventsTestServer(t, eventsTestResponse{status: http.StatusInternalServerError})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := client.TailEvents(ctx, gocloak.StaticToken("token"), "realm", gocloak.GetEventsParams{}, gocloak.TailEventsOptions{
+		Cursor:     &gocloak.EventCursor{},
+		MinBackoff: time.Hour,
+		OnError:    func(error) { cancel() },
+	})
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "no events expected")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the tail did not stop after the context was done")
+	}
+}
@@ -70,6 +70,13 @@ type GoCloakIface interface {
 	// DirectNakedImpersonationTokenExchange performs "Direct Naked Impersonation"
 	// See: https://www.keycloak.org/docs/latest/securing_apps/index.html#direct-naked-impersonation
 	DirectNakedImpersonationTokenExchange(ctx context.Context, clientID, clientSecret, realm, userID string) (*JWT, error)
+	// ImpersonateUser starts a browser session of the user with the given id on behalf of the admin owning the token.
+	// It returns the url to redirect the browser to and the session cookies issued by Keycloak, which must be passed
+	// on to the browser of the support engineer. The cookies are removed from the cookie jar of the client, so later
+	// requests of the client are not sent with the session of the impersonated user.
+	// The admin needs the "impersonation" role of the "realm-management" client of the realm, or of the "<realm>-realm"
+	// client if the token was issued by the master realm, and the impersonation feature must not be disabled.
+	ImpersonateUser(ctx context.Context, token, realm, userID string) (*ImpersonationRepresentation, error)
 	// LoginClientSignedJWT performs a login with client credentials and signed jwt claims
 	LoginClientSignedJWT(ctx context.Context, clientID, realm string, key any, signedMethod jwt.SigningMethod, expiresAt *jwt.NumericDate) (*JWT, error)
 	// Login performs a login with user credentials and a client
@@ -82,6 +89,13 @@ type GoCloakIface interface {
 	LogoutPublicClient(ctx context.Context, clientID, realm, accessToken, refreshToken string) error
 	// LogoutAllSessions logs out all sessions of a user given an id.
 	LogoutAllSessions(ctx context.Context, accessToken, realm, userID string) error
+	// GetUserConsents returns the consents and offline tokens the user granted, one entry per client
+	GetUserConsents(ctx context.Context, accessToken, realm, userID string) ([]*UserConsentRepresentation, error)
+	// RevokeClientsConsents revokes the consents and offline tokens every user of the realm granted to the given clients,
+	// e.g. when decommissioning applications. The clients are identified by their clientId.
+	// It walks through all users of the realm and stops at the first error. It is safe to call it again after a failure.
+	// It returns the number of revoked consents.
+	RevokeClientsConsents(ctx context.Context, accessToken, realm string, clientIDs []string) (int, error)
 	// RevokeUserConsents revokes the given user consent.
 	RevokeUserConsents(ctx context.Context, accessToken, realm, userID, clientID string) error
 	// LogoutUserSession logs out a single sessions of a user given a session id
@@ -116,6 +130,14 @@ type GoCloakIface interface {
 	UpdateClientRepresentation(ctx context.Context, accessToken, realm string, updatedClient Client) (*Client, error)
 	// UpdateClientManagementPermissions updates the given client management permissions
 	UpdateClientManagementPermissions(ctx context.Context, accessToken, realm string, idOfClient string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error)
+	// UpdateRealmRoleManagementPermissions updates the management permissions of the realm role with the given name
+	UpdateRealmRoleManagementPermissions(ctx context.Context, accessToken, realm string, roleName string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error)
+	// UpdateRoleByIDManagementPermissions updates the management permissions of the role with the given id
+	UpdateRoleByIDManagementPermissions(ctx context.Context, accessToken, realm string, roleID string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error)
+	// UpdateClientRoleManagementPermissions updates the management permissions of the client role with the given name
+	UpdateClientRoleManagementPermissions(ctx context.Context, accessToken, realm string, idOfClient, roleName string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error)
+	// UpdateIdentityProviderManagementPermissions updates the management permissions of the identity provider with the given alias
+	UpdateIdentityProviderManagementPermissions(ctx context.Context, accessToken, realm string, alias string, managementPermissions ManagementPermissionRepresentation) (*ManagementPermissionRepresentation, error)
 	// UpdateRole updates the given role.
 	UpdateRole(ctx context.Context, token, realm, idOfClient string, role Role) error
 	// UpdateClientScope updates the given client scope.
@@ -192,6 +214,52 @@ type GoCloakIface interface {
 	GetClientServiceAccount(ctx context.Context, token, realm, idOfClient string) (*User, error)
 	// RegenerateClientSecret triggers the creation of the new client secret.
 	RegenerateClientSecret(ctx context.Context, token, realm, idOfClient string) (*CredentialRepresentation, error)
+	// GetClientRotatedSecret returns the rotated client secret which stays valid until it expires or is invalidated.
+	// Keycloak only keeps a rotated secret if a client policy with the secret-rotation executor applies to the client.
+	GetClientRotatedSecret(ctx context.Context, token, realm, idOfClient string) (*CredentialRepresentation, error)
+	// InvalidateClientRotatedSecret invalidates the rotated client secret, so only the current secret remains valid
+	InvalidateClientRotatedSecret(ctx context.Context, token, realm, idOfClient string) error
+	// GetClientSecretRotationInfo returns the current and the rotated client secret together with their creation and expiration times.
+	// RotatedSecret is nil if the client has no valid rotated secret.
+	GetClientSecretRotationInfo(ctx context.Context, token, realm, idOfClient string) (*ClientSecretRotationInfo, error)
+	// RotateClientSecret regenerates the client secret and returns the new rotation state.
+	// If secret rotation is enabled for the client, the previous secret stays valid as rotated secret
+	// until it expires or InvalidateClientRotatedSecret is called, which allows a zero-downtime rotation.
+	RotateClientSecret(ctx context.Context, token, realm, idOfClient string) (*ClientSecretRotationInfo, error)
+	// GetClientCertificate returns the certificate info of a client for the given attribute prefix,
+	// e.g. "jwt.credential" for clients using private_key_jwt or "saml.signing" for SAML clients.
+	GetClientCertificate(ctx context.Context, token, realm, idOfClient, attr string) (*CertificateRepresentation, error)
+	// GenerateClientCertificate generates a new certificate with a new key pair for the client
+	GenerateClientCertificate(ctx context.Context, token, realm, idOfClient, attr string) (*CertificateRepresentation, error)
+	// DownloadClientCertificate returns a keystore file for the client, containing the private key and public certificate
+	DownloadClientCertificate(ctx context.Context, token, realm, idOfClient, attr string, config ClientKeyStoreConfig) ([]byte, error)
+	// GenerateAndDownloadClientCertificate generates a new key pair and certificate and returns the private key
+	// in the requested keystore format. Only the public certificate is saved in Keycloak.
+	GenerateAndDownloadClientCertificate(ctx context.Context, token, realm, idOfClient, attr string, config ClientKeyStoreConfig) ([]byte, error)
+	// UploadClientCertificate uploads a certificate and, depending on the keystore format, its private key
+	UploadClientCertificate(ctx context.Context, token, realm, idOfClient, attr string, params UploadClientCertificateParams, fileName string, fileBody io.Reader) (*CertificateRepresentation, error)
+	// UploadClientCertificateOnly uploads only a certificate, not the private key
+	UploadClientCertificateOnly(ctx context.Context, token, realm, idOfClient, attr string, params UploadClientCertificateParams, fileName string, fileBody io.Reader) (*CertificateRepresentation, error)
+	// GenerateExampleAccessToken returns the payload of an example access token the given user would receive
+	// for the client and the requested scope
+	GenerateExampleAccessToken(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) (jwt.MapClaims, error)
+	// GenerateExampleIDToken returns the payload of an example id token the given user would receive
+	// for the client and the requested scope
+	GenerateExampleIDToken(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) (jwt.MapClaims, error)
+	// GenerateExampleUserInfo returns the example userinfo response the given user would receive
+	// for the client and the requested scope. The audience parameter is ignored.
+	GenerateExampleUserInfo(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) (map[string]any, error)
+	// GetEvaluateScopesProtocolMappers returns all protocol mappers which are used when generating tokens for the client
+	// and the requested scope, including the mappers of the client scopes linked with the client
+	GetEvaluateScopesProtocolMappers(ctx context.Context, token, realm, idOfClient string, params EvaluateScopesParams) ([]*ProtocolMapperEvaluationRepresentation, error)
+	// GetEvaluateScopesGrantedScopeMappings returns the effective roles of a role container which the client
+	// is allowed to have in its access token for the requested scope.
+	// roleContainerID is either the realm name or the id of a client.
+	GetEvaluateScopesGrantedScopeMappings(ctx context.Context, token, realm, idOfClient, roleContainerID string, params EvaluateScopesParams) ([]*Role, error)
+	// GetEvaluateScopesNotGrantedScopeMappings returns the roles of a role container which the client
+	// can not have in its access token for the requested scope.
+	// roleContainerID is either the realm name or the id of a client.
+	GetEvaluateScopesNotGrantedScopeMappings(ctx context.Context, token, realm, idOfClient, roleContainerID string, params EvaluateScopesParams) ([]*Role, error)
 	// GetClientOfflineSessions returns offline sessions associated with the client
 	GetClientOfflineSessions(ctx context.Context, token, realm, idOfClient string, params ...GetClientUserSessionsParams) ([]*UserSessionRepresentation, error)
 	// GetClientUserSessions returns user sessions associated with the client
@@ -266,6 +334,14 @@ type GoCloakIface interface {
 	// GetClientManagementPermissions returns whether client Authorization permissions have been initialized or not and a reference
 	// to the managed permissions
 	GetClientManagementPermissions(ctx context.Context, token, realm string, idOfClient string) (*ManagementPermissionRepresentation, error)
+	// GetRealmRoleManagementPermissions returns the management permissions of the realm role with the given name
+	GetRealmRoleManagementPermissions(ctx context.Context, token, realm string, roleName string) (*ManagementPermissionRepresentation, error)
+	// GetRoleByIDManagementPermissions returns the management permissions of the role with the given id
+	GetRoleByIDManagementPermissions(ctx context.Context, token, realm string, roleID string) (*ManagementPermissionRepresentation, error)
+	// GetClientRoleManagementPermissions returns the management permissions of the client role with the given name
+	GetClientRoleManagementPermissions(ctx context.Context, token, realm string, idOfClient, roleName string) (*ManagementPermissionRepresentation, error)
+	// GetIdentityProviderManagementPermissions returns the management permissions of the identity provider with the given alias
+	GetIdentityProviderManagementPermissions(ctx context.Context, token, realm string, alias string) (*ManagementPermissionRepresentation, error)
 	// CreateRealmRole creates a role in a realm
 	CreateRealmRole(ctx context.Context, token string, realm string, role Role) (string, error)
 	// GetRealmRole returns a role from a realm by role's name
@@ -326,6 +402,24 @@ type GoCloakIface interface {
 	ClearUserCache(ctx context.Context, token, realm string) error
 	// ClearKeysCache clears realm cache
 	ClearKeysCache(ctx context.Context, token, realm string) error
+	// GetRealmLocalizationLocales returns the locales which have localization texts in the realm
+	GetRealmLocalizationLocales(ctx context.Context, token, realm string) ([]string, error)
+	// GetRealmLocalizationTexts returns all localization texts of the realm for the given locale
+	GetRealmLocalizationTexts(ctx context.Context, token, realm, locale string) (map[string]string, error)
+	// ImportRealmLocalizationTexts creates or overwrites the given localization texts of the realm for the given locale
+	ImportRealmLocalizationTexts(ctx context.Context, token, realm, locale string, texts map[string]string) error
+	// DeleteRealmLocalizationTexts deletes all localization texts of the realm for the given locale
+	DeleteRealmLocalizationTexts(ctx context.Context, token, realm, locale string) error
+	// GetRealmLocalizationText returns the localization text of the realm for the given locale and key
+	GetRealmLocalizationText(ctx context.Context, token, realm, locale, key string) (string, error)
+	// UpdateRealmLocalizationText creates or updates the localization text of the realm for the given locale and key
+	UpdateRealmLocalizationText(ctx context.Context, token, realm, locale, key, text string) error
+	// DeleteRealmLocalizationText deletes the localization text of the realm for the given locale and key
+	DeleteRealmLocalizationText(ctx context.Context, token, realm, locale, key string) error
+	// SyncRealmLocalizationTexts makes the localization texts of the realm match the given locale -> key -> text map.
+	// Only the differences are applied: changed and missing texts are updated and texts which are not part
+	// of the desired locale are deleted. Locales which are not part of the map are left untouched.
+	SyncRealmLocalizationTexts(ctx context.Context, token, realm string, texts map[string]map[string]string) error
 	// GetAuthenticationFlows get all authentication flows from a realm
 	GetAuthenticationFlows(ctx context.Context, token, realm string) ([]*AuthenticationFlowRepresentation, error)
 	// GetAuthenticationFlow get an authentication flow with the given ID
@@ -336,6 +430,8 @@ type GoCloakIface interface {
 	UpdateAuthenticationFlow(ctx context.Context, token, realm string, flow AuthenticationFlowRepresentation, authenticationFlowID string) (*AuthenticationFlowRepresentation, error)
 	// DeleteAuthenticationFlow deletes a flow in a realm with the given ID
 	DeleteAuthenticationFlow(ctx context.Context, token, realm, flowID string) error
+	// CopyAuthenticationFlow copies the authentication flow with the given alias, including its executions, under a new alias
+	CopyAuthenticationFlow(ctx context.Context, token, realm, flowAlias, newName string) error
 	// CreateAuthenticatorConfig creates a new authenticator configuration and returns its ID
 	CreateAuthenticatorConfig(ctx context.Context, token, realm string, config AuthenticatorConfigRepresentation) (string, error)
 	// GetAuthenticatorConfigDescription gets the configuration description for an authenticator provider
@@ -360,6 +456,23 @@ type GoCloakIface interface {
 	GetAuthenticationExecutionConfig(ctx context.Context, token, realm, executionID, configID string) (*AuthenticatorConfigRepresentation, error)
 	// CreateAuthenticationExecutionFlow creates a new execution for the given flow name in the given realm
 	CreateAuthenticationExecutionFlow(ctx context.Context, token, realm, flow string, executionFlow CreateAuthenticationExecutionFlowRepresentation) error
+	// RaiseAuthenticationExecutionPriority moves an execution one position up within its flow
+	RaiseAuthenticationExecutionPriority(ctx context.Context, token, realm, executionID string) error
+	// LowerAuthenticationExecutionPriority moves an execution one position down within its flow
+	LowerAuthenticationExecutionPriority(ctx context.Context, token, realm, executionID string) error
+	// OrderAuthenticationExecutions raises and lowers the priority of the executions of a flow until their order matches
+	// the given execution ids. The ids must list every execution of one level of the flow exactly once, i.e. all top
+	// level executions of the flow, or all executions of one of its sub-flows.
+	// The executions are read back afterwards and an error is returned if the server order still differs.
+	OrderAuthenticationExecutions(ctx context.Context, token, realm, flowAlias string, executionIDs []string) error
+	// GetAuthenticatorProviders returns the authenticator providers available for executions
+	GetAuthenticatorProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error)
+	// GetFormProviders returns the form providers available for form flows
+	GetFormProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error)
+	// GetFormActionProviders returns the form action providers available for executions of form flows
+	GetFormActionProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error)
+	// GetClientAuthenticatorProviders returns the client authenticator providers available for client flows
+	GetClientAuthenticatorProviders(ctx context.Context, token, realm string) ([]*AuthenticatorProviderRepresentation, error)
 	// CreateUser creates the given user in the given realm and returns it's userID
 	// Note: Keycloak has not documented what members of the User object are actually being accepted, when creating a user.
 	// Things like RealmRoles must be attached using followup calls to the respective functions.
@@ -368,12 +481,20 @@ type GoCloakIface interface {
 	DeleteUser(ctx context.Context, token, realm, userID string) error
 	// GetUserByID fetches a user from the given realm with the given userID
 	GetUserByID(ctx context.Context, accessToken, realm, userID string) (*User, error)
+	// GetUserByIDWithProfileMetadata fetches a user from the given realm with the given userID
+	// together with the metadata of its user profile
+	GetUserByIDWithProfileMetadata(ctx context.Context, accessToken, realm, userID string) (*User, error)
+	// GetUserUnmanagedAttributes returns the attributes of a user which are not managed by the user profile
+	GetUserUnmanagedAttributes(ctx context.Context, accessToken, realm, userID string) (map[string][]string, error)
 	// GetUserCount gets the user count in the realm
 	GetUserCount(ctx context.Context, token string, realm string, params GetUsersParams) (int, error)
 	// GetUserGroups get all groups for user
 	GetUserGroups(ctx context.Context, token, realm, userID string, params GetGroupsParams) ([]*Group, error)
 	// GetUserProfileConfig retrieves the user profile configuration for a realm
 	GetUserProfileConfig(ctx context.Context, token, realm string) (*UserProfileConfig, error)
+	// GetUserProfileMetadata retrieves the metadata of the user profile for a realm, i.e. the managed attributes
+	// and their validators as seen by the admin
+	GetUserProfileMetadata(ctx context.Context, token, realm string) (*UserProfileMetadata, error)
 	// GetUsers get all users in realm
 	// Default number of results per page is 100, use GetUsersParams to specify it explicitly or to set offset for pagination
 	GetUsers(ctx context.Context, token, realm string, params GetUsersParams) ([]*User, error)
@@ -427,6 +548,16 @@ type GoCloakIface interface {
 	DeleteUserFederatedIdentity(ctx context.Context, token, realm, userID, providerID string) error
 	// GetUserBruteForceDetectionStatus fetches a user status regarding brute force protection
 	GetUserBruteForceDetectionStatus(ctx context.Context, accessToken, realm, userID string) (*BruteForceStatus, error)
+	// ClearUserLoginFailures clears the login failures of a user, which unlocks a user locked by the brute force detection
+	ClearUserLoginFailures(ctx context.Context, accessToken, realm, userID string) error
+	// ClearAllLoginFailures clears the login failures of all users of the realm, which unlocks all users
+	// locked by the brute force detection
+	ClearAllLoginFailures(ctx context.Context, accessToken, realm string) error
+	// GetLockedUsers returns the users matching params which are locked by the brute force detection.
+	// Keycloak has no endpoint listing locked users, so the brute force status of every matching user is fetched,
+	// with up to 10 concurrent requests. Use params to narrow the users down, e.g. with Search.
+	// All pages starting at params.First are walked, params.Max sets the page size.
+	GetLockedUsers(ctx context.Context, accessToken, realm string, params GetUsersParams) ([]*LockedUser, error)
 	// CreateIdentityProvider creates an identity provider in a realm
 	CreateIdentityProvider(ctx context.Context, token string, realm string, providerRep IdentityProviderRepresentation) (string, error)
 	// GetIdentityProviders returns list of identity providers in a realm
@@ -461,12 +592,23 @@ type GoCloakIface interface {
 	GetResourceClient(ctx context.Context, token, realm, resourceID string) (*ResourceRepresentation, error)
 	// GetResources returns resources associated with the client, using access token from admin
 	GetResources(ctx context.Context, token, realm, idOfClient string, params GetResourceParams) ([]*ResourceRepresentation, error)
-	// GetResourcesClient returns resources associated with the client, using access token from client
+	// GetResourcesClient returns resources associated with the client, using access token from client.
+	// If params.Deep is set, the resources are returned by a single request instead of one request per resource.
 	GetResourcesClient(ctx context.Context, token, realm string, params GetResourceParams) ([]*ResourceRepresentation, error)
 	// GetResourceServer returns resource server settings.
+	// The result is the export of the resource server: it includes the resources, scopes, policies and permissions,
+	// which reference each other by name, and can be imported into another client with ImportResourceServer.
 	// The access token must have the realm view_clients role on its service
 	// account to be allowed to call this endpoint.
 	GetResourceServer(ctx context.Context, token, realm, idOfClient string) (*ResourceServerRepresentation, error)
+	// UpdateResourceServer updates the settings of a client's resource server, i.e. the policy enforcement mode,
+	// the decision strategy and whether remote resource management is allowed.
+	// Resources, scopes and policies in the representation are ignored, use ImportResourceServer for them.
+	UpdateResourceServer(ctx context.Context, token, realm, idOfClient string, resourceServer ResourceServerRepresentation) error
+	// ImportResourceServer imports the settings, resources, scopes, policies and permissions of a resource server,
+	// typically exported from another environment with GetResourceServer. References between them are resolved by name,
+	// and existing resources, scopes and policies with the same name are updated.
+	ImportResourceServer(ctx context.Context, token, realm, idOfClient string, resourceServer ResourceServerRepresentation) error
 	// UpdateResource updates a resource associated with the client, using access token from admin
 	UpdateResource(ctx context.Context, token, realm, idOfClient string, resource ResourceRepresentation) error
 	// UpdateResourceClient updates a resource associated with the client, using access token from client
@@ -503,6 +645,20 @@ type GoCloakIface interface {
 	UpdatePolicy(ctx context.Context, token, realm, idOfClient string, policy PolicyRepresentation) error
 	// DeletePolicy deletes a policy associated with the client
 	DeletePolicy(ctx context.Context, token, realm, idOfClient, policyID string) error
+	// GetTypedPolicy returns a policy of the given type associated with the client, e.g. a *RolePolicy for type "role".
+	// Policies of types without a typed representation are returned as *GenericPolicy.
+	GetTypedPolicy(ctx context.Context, token, realm, idOfClient, policyType, policyID string) (TypedPolicy, error)
+	// GetTypedPolicies returns the policies associated with the client decoded into their typed representations,
+	// see DecodePolicyRepresentation. Set params.Permission to false to leave out the permissions.
+	GetTypedPolicies(ctx context.Context, token, realm, idOfClient string, params GetPolicyParams) ([]TypedPolicy, error)
+	// CreateTypedPolicy creates a policy associated with the client, the type is taken from the given policy
+	CreateTypedPolicy(ctx context.Context, token, realm, idOfClient string, policy TypedPolicy) (TypedPolicy, error)
+	// UpdateTypedPolicy updates a policy associated with the client, the type is taken from the given policy
+	UpdateTypedPolicy(ctx context.Context, token, realm, idOfClient string, policy TypedPolicy) error
+	// EvaluatePolicies evaluates the permissions of a client's resource server for a user, like the "Evaluate" tab
+	// of the admin console. The response contains the decision per resource together with the permissions and
+	// policies which granted or denied access.
+	EvaluatePolicies(ctx context.Context, token, realm, idOfClient string, request PolicyEvaluationRequest) (*PolicyEvaluationResponse, error)
 	// GetAuthorizationPolicyAssociatedPolicies returns a client's associated policies of specific policy with the given policy id, using access token from admin
 	GetAuthorizationPolicyAssociatedPolicies(ctx context.Context, token, realm, idOfClient, policyID string) ([]*PolicyRepresentation, error)
 	// GetAuthorizationPolicyResources returns a client's resources of specific policy with the given policy id, using access token from admin
@@ -527,10 +683,39 @@ type GoCloakIface interface {
 	GetPermissionResources(ctx context.Context, token, realm, idOfClient, permissionID string) ([]*PermissionResource, error)
 	// GetScopePermissions returns permissions associated with the client scope
 	GetScopePermissions(ctx context.Context, token, realm, idOfClient, idOfScope string) ([]*PolicyRepresentation, error)
+	// GetResourcePermissions returns the permissions protecting the resource with the given id,
+	// i.e. the resource based permissions of the resource and the scope based permissions of its scopes
+	GetResourcePermissions(ctx context.Context, token, realm, idOfClient, resourceID string) ([]*PolicyRepresentation, error)
+	// GetResourceAttributes returns the attributes of the resource with the given id
+	GetResourceAttributes(ctx context.Context, token, realm, idOfClient, resourceID string) (map[string][]string, error)
+	// SearchPermission returns the permission of the client with the given name, or nil if there is none
+	SearchPermission(ctx context.Context, token, realm, idOfClient, name string) (*PermissionRepresentation, error)
+	// SearchPolicy returns the policy of the client with the given name, or nil if there is none.
+	// Permissions are found as well, as they are policies of the types "resource" and "scope".
+	SearchPolicy(ctx context.Context, token, realm, idOfClient, name string) (*PolicyRepresentation, error)
 	// GetPermissionScopes returns a client's scopes configured for the given permission id
 	GetPermissionScopes(ctx context.Context, token, realm, idOfClient, permissionID string) ([]*PermissionScope, error)
 	// GetPermissions returns permissions associated with the client
 	GetPermissions(ctx context.Context, token, realm, idOfClient string, params GetPermissionParams) ([]*PermissionRepresentation, error)
+	// GetAdminPermissionsClient returns the admin-permissions client of the realm, which holds the resource server
+	// of the fine-grained admin permissions v2. These are available from Keycloak 26.2 and have to be enabled
+	// with the adminPermissionsEnabled setting of the realm. They replace the management permissions of
+	// e.g. UpdateUsersManagementPermissions, which are only used in realms without them.
+	// Pass the ID of the returned client to GetAdminPermissions, CreateAdminPermission and EvaluateAdminPermissions.
+	GetAdminPermissionsClient(ctx context.Context, token, realm string) (*Client, error)
+	// GetAdminPermissions returns the fine-grained admin permissions v2 of the realm, idOfClient is the ID of the client
+	// returned by GetAdminPermissionsClient. Use params.ResourceType to get the permissions of a resource type only.
+	GetAdminPermissions(ctx context.Context, token, realm, idOfClient string, params GetPermissionParams) ([]*PermissionRepresentation, error)
+	// CreateAdminPermission creates a fine-grained admin permission v2 on the client returned by
+	// GetAdminPermissionsClient. The permission grants its scopes, e.g. "view" or "manage", for the resources of
+	// its resource type, or for all resources of the type if no resources are given, to everyone matching its
+	// policies. Policies are created on the same client. Use UpdatePermission and DeletePermission with the ID of
+	// that client to change the permission.
+	CreateAdminPermission(ctx context.Context, token, realm, idOfClient string, permission PermissionRepresentation) (*PermissionRepresentation, error)
+	// EvaluateAdminPermissions evaluates the fine-grained admin permissions v2 of a user or roles for the resources
+	// of request.ResourceType, like the "Evaluation" tab of the permissions in the admin console.
+	// idOfClient is the ID of the client returned by GetAdminPermissionsClient.
+	EvaluateAdminPermissions(ctx context.Context, token, realm, idOfClient string, request PolicyEvaluationRequest) (*PolicyEvaluationResponse, error)
 	// CreatePermissionTicket creates a permission ticket, using access token from client
 	CreatePermissionTicket(ctx context.Context, token, realm string, permissions []CreatePermissionTicketParams) (*PermissionTicketResponseRepresentation, error)
 	// GrantUserPermission lets resource owner grant permission for specific resource ID to specific user ID
@@ -567,6 +752,28 @@ type GoCloakIface interface {
 	GetEvents(ctx context.Context, token string, realm string, params GetEventsParams) ([]*EventRepresentation, error)
 	// GetAdminEvents returns admin events
 	GetAdminEvents(ctx context.Context, token string, realm string, params GetAdminEventsParams) ([]*AdminEventRepresentation, error)
+	// TailEvents polls the events of the realm and sends every new event to the returned channel, oldest first.
+	// Keycloak returns events newest first and may insert new events while paging, so every poll collects
+	// all pages since the cursor and drops the events which were already sent. Events are identified by their
+	// id, or by a hash of their content on servers which do not return ids.
+	// Failed polls are reported to options.OnError and retried with exponential backoff.
+	// The channel is closed once ctx is done.
+	// DateFrom, DateTo, First and Max of params are set by the tail itself, use options.Cursor to resume from a
+	// given position. If they are set, or params can not be encoded as query parameters, the error is reported
+	// to options.OnError and the returned channel is closed right away.
+	TailEvents(ctx context.Context, token TokenProvider, realm string, params GetEventsParams, options TailEventsOptions) <-chan *TailedEvent
+	// TailAdminEvents polls the admin events of the realm and sends every new admin event to the returned channel,
+	// oldest first. See TailEvents for details.
+	TailAdminEvents(ctx context.Context, token TokenProvider, realm string, params GetAdminEventsParams, options TailEventsOptions) <-chan *TailedAdminEvent
+	// ClearEvents deletes all events of the realm
+	ClearEvents(ctx context.Context, token, realm string) error
+	// ClearAdminEvents deletes all admin events of the realm
+	ClearAdminEvents(ctx context.Context, token, realm string) error
+	// GetEventsConfig returns the events configuration of the realm
+	GetEventsConfig(ctx context.Context, token, realm string) (*RealmEventsConfigRepresentation, error)
+	// UpdateEventsConfig updates the events configuration of the realm, e.g. the enabled event types,
+	// the event listeners and the expiration of stored events
+	UpdateEventsConfig(ctx context.Context, token, realm string, config RealmEventsConfigRepresentation) error
 	// GetClientScopesScopeMappingsRealmRolesAvailable returns realm-level roles that are available to attach to this client scope
 	GetClientScopesScopeMappingsRealmRolesAvailable(ctx context.Context, token, realm, clientScopeID string) ([]*Role, error)
 	// GetClientScopesScopeMappingsRealmRoles returns roles associated with a client-scope
@@ -587,6 +794,23 @@ type GoCloakIface interface {
 	UpdateRequiredAction(ctx context.Context, token string, realm string, requiredAction RequiredActionProviderRepresentation) error
 	// DeleteRequiredAction updates a required action for a given realm
 	DeleteRequiredAction(ctx context.Context, token string, realm string, alias string) error
+	// GetRequiredActionConfig gets the configuration of a required action, e.g. the max age of UPDATE_PASSWORD
+	GetRequiredActionConfig(ctx context.Context, token string, realm string, alias string) (*RequiredActionConfigRepresentation, error)
+	// UpdateRequiredActionConfig updates the configuration of a required action
+	UpdateRequiredActionConfig(ctx context.Context, token string, realm string, alias string, config RequiredActionConfigRepresentation) error
+	// DeleteRequiredActionConfig resets the configuration of a required action to its defaults
+	DeleteRequiredActionConfig(ctx context.Context, token string, realm string, alias string) error
+	// GetRequiredActionConfigDescription gets the configuration properties supported by a required action
+	GetRequiredActionConfigDescription(ctx context.Context, token string, realm string, alias string) (*RequiredActionConfigInfoRepresentation, error)
+	// RaiseRequiredActionPriority moves a required action one position up
+	RaiseRequiredActionPriority(ctx context.Context, token string, realm string, alias string) error
+	// LowerRequiredActionPriority moves a required action one position down
+	LowerRequiredActionPriority(ctx context.Context, token string, realm string, alias string) error
+	// OrderRequiredActions raises and lowers the priority of the required actions with the given aliases until they are
+	// the first required actions of the realm in the given order. The other required actions keep their relative order
+	// after them. The required actions are read back afterwards and an error is returned if the server order differs,
+	// e.g. because two required actions have the same priority.
+	OrderRequiredActions(ctx context.Context, token string, realm string, aliases []string) error
 	// CreateClientScopesScopeMappingsClientRoles attaches a client role to a client scope (not client's scope)
 	CreateClientScopesScopeMappingsClientRoles(ctx context.Context, token, realm, idOfClientScope, idOfClient string, roles []Role) error
 	// GetClientScopesScopeMappingsClientRolesAvailable returns available (i.e. not attached via
@@ -703,4 +927,26 @@ type GoCloakIface interface {
 	// RemoveMemberFromOrganizationGroup removes the user with the specified id from the organization group
 	// DELETE /admin/realms/{realm}/organizations/{id}/groups/{group-id}/members/{user-id}
 	RemoveMemberFromOrganizationGroup(ctx context.Context, token, realm, idOfOrganization, idOfGroup, idOfUser string) error
+	// CreateClientInitialAccess creates a new initial access token that can be used to register clients
+	CreateClientInitialAccess(ctx context.Context, token, realm string, params ClientInitialAccessCreatePresentation) (*ClientInitialAccessPresentation, error)
+	// GetClientInitialAccess returns the initial access tokens of a realm
+	GetClientInitialAccess(ctx context.Context, token, realm string) ([]*ClientInitialAccessPresentation, error)
+	// DeleteClientInitialAccess deletes the initial access token with the given id
+	DeleteClientInitialAccess(ctx context.Context, token, realm, initialAccessID string) error
+	// RegisterClient registers a new client using the OpenID Connect Dynamic Client Registration endpoint (RFC 7591).
+	// The initialAccessToken may be empty if the realm allows anonymous registration from trusted hosts.
+	// The returned representation holds the registration access token required for subsequent calls.
+	// POST /realms/{realm}/clients-registrations/openid-connect
+	RegisterClient(ctx context.Context, initialAccessToken, realm string, client OIDCClientRepresentation) (*OIDCClientRepresentation, error)
+	// GetRegisteredClient returns the metadata of a dynamically registered client (RFC 7592)
+	// GET /realms/{realm}/clients-registrations/openid-connect/{client-id}
+	GetRegisteredClient(ctx context.Context, registrationAccessToken, realm, clientID string) (*OIDCClientRepresentation, error)
+	// UpdateRegisteredClient updates the metadata of a dynamically registered client (RFC 7592).
+	// Keycloak rotates the registration access token on every update, so the token of the
+	// returned representation must be used for subsequent calls.
+	// PUT /realms/{realm}/clients-registrations/openid-connect/{client-id}
+	UpdateRegisteredClient(ctx context.Context, registrationAccessToken, realm string, client OIDCClientRepresentation) (*OIDCClientRepresentation, error)
+	// DeleteRegisteredClient deletes a dynamically registered client (RFC 7592)
+	// DELETE /realms/{realm}/clients-registrations/openid-connect/{client-id}
+	DeleteRegisteredClient(ctx context.Context, registrationAccessToken, realm, clientID string) error
 }
@@ -0,0 +1,42 @@
+package gocloak_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+func TestImpersonateUserKeepsSessionOutOfCookieJar(t *testing.T) {
+	var cookies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/users/u1/impersonation") {
+			http.SetCookie(w, &http.Cookie{Name: "KEYCLOAK_IDENTITY", Value: "identity", Path: "/realms/realm/", HttpOnly: true})
+			http.SetCookie(w, &http.Cookie{Name: "KEYCLOAK_SESSION", Value: "session", Path: "/realms/realm/"})
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"sameRealm":true,"redirect":"http://localhost/realms/realm/account"}`))
+			return
+		}
+		cookies = append(cookies, r.Header.Values("Cookie")...)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sub":"u1"}`))
+	}))
+	defer server.Close()
+
+	client := gocloak.NewClient(server.URL)
+	impersonation, err := client.ImpersonateUser(context.Background(), "token", "realm", "u1")
+	require.NoError(t, err)
+	assert.True(t, gocloak.PBool(impersonation.SameRealm))
+	require.Len(t, impersonation.Cookies, 2)
+	assert.Equal(t, "KEYCLOAK_IDENTITY", impersonation.Cookies[0].Name)
+
+	_, err = client.GetUserInfo(context.Background(), "token", "realm")
+	require.NoError(t, err)
+	assert.Empty(t, cookies, "later requests must not carry the session of the impersonated user")
+}
@@ -392,6 +392,46 @@ func TestStringerOmitEmpty(t *testing.T) {
 		&gocloak.GetOrganizationsParams{},
 		&gocloak.OrganizationDomainRepresentation{},
 		&gocloak.OrganizationRepresentation{},
+		&gocloak.ClientInitialAccessCreatePresentation{},
+		&gocloak.ClientInitialAccessPresentation{},
+		&gocloak.OIDCClientRepresentation{},
+		&gocloak.CertificateRepresentation{},
+		&gocloak.ClientKeyStoreConfig{},
+		&gocloak.UploadClientCertificateParams{},
+		&gocloak.ClientSecretRotationInfo{},
+		&gocloak.EvaluateScopesParams{},
+		&gocloak.ProtocolMapperEvaluationRepresentation{},
+		&gocloak.RealmEventsConfigRepresentation{},
+		&gocloak.EventCursor{},
+		&gocloak.ImpersonationRepresentation{},
+		&gocloak.UserConsentRepresentation{},
+		&gocloak.UserConsentAdditionalGrant{},
+		&gocloak.UserProfileMetadata{},
+		&gocloak.UserProfileAttributeMetadata{},
+		&gocloak.UserProfileAttributeGroupMetadata{},
+		&gocloak.LockedUser{},
+		&gocloak.AuthenticatorProviderRepresentation{},
+		&gocloak.RequiredActionConfigRepresentation{},
+		&gocloak.RequiredActionConfigInfoRepresentation{},
+		&gocloak.PolicyEvaluationRequest{},
+		&gocloak.PolicyEvaluationResponse{},
+		&gocloak.EvaluationResultRepresentation{},
+		&gocloak.PolicyResultRepresentation{},
+		&gocloak.TypedPolicyBase{},
+		&gocloak.RolePolicy{},
+		&gocloak.UserPolicy{},
+		&gocloak.GroupPolicy{},
+		&gocloak.ClientPolicy{},
+		&gocloak.TimePolicy{},
+		&gocloak.AggregatePolicy{},
+		&gocloak.GenericPolicy{},
+		&gocloak.RegexPolicyRepresentation{},
+		&gocloak.ClientScopePolicyRepresentation{},
+		&gocloak.ClientScopeDefinition{},
+		&gocloak.RegexPolicy{},
+		&gocloak.ClientScopePolicy{},
+		&gocloak.AuthorizationSchema{},
+		&gocloak.AuthorizationSchemaResourceType{},
 	}
 
 	for _, custom := range customs {
@@ -3,9 +3,11 @@ package gocloak
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -219,6 +221,7 @@ type User struct {
 	Groups                     []string                   `json:"groups,omitempty"`
 	ServiceAccountClientID     *string                    `json:"serviceAccountClientId,omitempty"`
 	Credentials                []CredentialRepresentation `json:"credentials,omitempty"`
+	UserProfileMetadata        *UserProfileMetadata       `json:"userProfileMetadata,omitempty"`
 }
 
 // SetPasswordRequest sets a new password
@@ -524,8 +527,34 @@ type ResourceServerRepresentation struct {
 	Resources                     []ResourceRepresentation `json:"resources,omitempty"`
 	Scopes                        []ScopeRepresentation    `json:"scopes,omitempty"`
 	DecisionStrategy              *DecisionStrategy        `json:"decisionStrategy,omitempty"`
+	AuthorizationSchema           *AuthorizationSchema     `json:"authorizationSchema,omitempty"`
 }
 
+// AuthorizationSchema describes the resource types of a resource server,
+// e.g. the users, groups, clients and roles of the admin-permissions client
+type AuthorizationSchema struct {
+	ResourceTypes map[string]AuthorizationSchemaResourceType `json:"resourceTypes,omitempty"`
+}
+
+// AuthorizationSchemaResourceType describes a resource type and the scopes which can be granted for it
+type AuthorizationSchemaResourceType struct {
+	Type         *string             `json:"type,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	ScopeAliases map[string][]string `json:"scopeAliases,omitempty"`
+	GroupType    *string             `json:"groupType,omitempty"`
+}
+
+// AdminPermissionResourceType is an enum type for the resource types of fine-grained admin permissions v2
+type AdminPermissionResourceType string
+
+// AdminPermissionResourceType values
+const (
+	AdminPermissionResourceTypeUsers   AdminPermissionResourceType = "Users"
+	AdminPermissionResourceTypeGroups  AdminPermissionResourceType = "Groups"
+	AdminPermissionResourceTypeClients AdminPermissionResourceType = "Clients"
+	AdminPermissionResourceTypeRoles   AdminPermissionResourceType = "Roles"
+)
+
 // RoleDefinition represents a role in a RolePolicyRepresentation
 type RoleDefinition struct {
 	ID       *string `json:"id,omitempty"`
@@ -592,6 +621,8 @@ type PolicyRepresentation struct {
 	UserPolicyRepresentation
 	AggregatedPolicyRepresentation
 	GroupPolicyRepresentation
+	RegexPolicyRepresentation
+	ClientScopePolicyRepresentation
 }
 
 // RolePolicyRepresentation represents role based policies
@@ -649,6 +680,128 @@ type GroupDefinition struct {
 	ExtendChildren *bool   `json:"extendChildren,omitempty"`
 }
 
+// RegexPolicyRepresentation represents regex based policies
+type RegexPolicyRepresentation struct {
+	TargetClaim             *string `json:"targetClaim,omitempty"`
+	Pattern                 *string `json:"pattern,omitempty"`
+	TargetContextAttributes *bool   `json:"targetContextAttributes,omitempty"`
+}
+
+// ClientScopePolicyRepresentation represents client scope based policies
+type ClientScopePolicyRepresentation struct {
+	ClientScopes []ClientScopeDefinition `json:"clientScopes,omitempty"`
+}
+
+// ClientScopeDefinition represents a client scope in a ClientScopePolicyRepresentation
+type ClientScopeDefinition struct {
+	ID       *string `json:"id,omitempty"`
+	Required *bool   `json:"required,omitempty"`
+}
+
+// TypedPolicy is implemented by the typed policy representations, e.g. *RolePolicy or *UserPolicy.
+// It is used with CreateTypedPolicy, GetTypedPolicy, GetTypedPolicies and UpdateTypedPolicy.
+type TypedPolicy interface {
+	// PolicyType returns the type of the policy as used by Keycloak, e.g. "role"
+	PolicyType() string
+	// Base returns the fields shared by all policy types
+	Base() *TypedPolicyBase
+}
+
+// TypedPolicyBase holds the fields shared by all policy types
+type TypedPolicyBase struct {
+	ID               *string           `json:"id,omitempty"`
+	Name             *string           `json:"name,omitempty"`
+	Description      *string           `json:"description,omitempty"`
+	Type             *string           `json:"type,omitempty"`
+	Logic            *Logic            `json:"logic,omitempty"`
+	DecisionStrategy *DecisionStrategy `json:"decisionStrategy,omitempty"`
+	Owner            *string           `json:"owner,omitempty"`
+}
+
+// Base returns the fields shared by all policy types
+func (p *TypedPolicyBase) Base() *TypedPolicyBase { return p }
+
+// RolePolicy is a policy granting access to users with the given roles
+type RolePolicy struct {
+	TypedPolicyBase
+	RolePolicyRepresentation
+}
+
+// PolicyType returns "role"
+func (*RolePolicy) PolicyType() string { return "role" }
+
+// UserPolicy is a policy granting access to the given users
+type UserPolicy struct {
+	TypedPolicyBase
+	UserPolicyRepresentation
+}
+
+// PolicyType returns "user"
+func (*UserPolicy) PolicyType() string { return "user" }
+
+// GroupPolicy is a policy granting access to members of the given groups
+type GroupPolicy struct {
+	TypedPolicyBase
+	GroupPolicyRepresentation
+}
+
+// PolicyType returns "group"
+func (*GroupPolicy) PolicyType() string { return "group" }
+
+// ClientPolicy is a policy granting access to the given clients
+type ClientPolicy struct {
+	TypedPolicyBase
+	ClientPolicyRepresentation
+}
+
+// PolicyType returns "client"
+func (*ClientPolicy) PolicyType() string { return "client" }
+
+// TimePolicy is a policy granting access within the given time period
+type TimePolicy struct {
+	TypedPolicyBase
+	TimePolicyRepresentation
+}
+
+// PolicyType returns "time"
+func (*TimePolicy) PolicyType() string { return "time" }
+
+// RegexPolicy is a policy granting access if a claim or context attribute matches a regular expression
+type RegexPolicy struct {
+	TypedPolicyBase
+	RegexPolicyRepresentation
+}
+
+// PolicyType returns "regex"
+func (*RegexPolicy) PolicyType() string { return "regex" }
+
+// ClientScopePolicy is a policy granting access if the given client scopes are requested
+type ClientScopePolicy struct {
+	TypedPolicyBase
+	ClientScopePolicyRepresentation
+}
+
+// PolicyType returns "client-scope"
+func (*ClientScopePolicy) PolicyType() string { return "client-scope" }
+
+// AggregatePolicy is a policy combining other policies according to its decision strategy
+type AggregatePolicy struct {
+	TypedPolicyBase
+	AggregatedPolicyRepresentation
+}
+
+// PolicyType returns "aggregate"
+func (*AggregatePolicy) PolicyType() string { return "aggregate" }
+
+// GenericPolicy is a policy of a type without a typed representation, e.g. a js policy
+type GenericPolicy struct {
+	TypedPolicyBase
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// PolicyType returns the type of the policy
+func (p *GenericPolicy) PolicyType() string { return PString(p.Type) }
+
 // ResourceRepresentation is a representation of a Resource
 type ResourceRepresentation struct {
 	ID                 *string                      `json:"_id,omitempty"` // TODO: is marked "_optional" in template, input error or deliberate?
@@ -680,6 +833,56 @@ type ScopeRepresentation struct {
 	Resources   []ResourceRepresentation `json:"resources,omitempty"`
 }
 
+// DecisionEffect is an enum type for the result of a policy evaluation
+type DecisionEffect string
+
+// DecisionEffect values
+const (
+	Permit DecisionEffect = "PERMIT"
+	Deny   DecisionEffect = "DENY"
+)
+
+// PolicyEvaluationRequest is the request of EvaluatePolicies.
+// The identity is either the user with UserID or, if UserID is not set, a user with the roles in RoleIDs.
+// Resources without an ID are matched by name, and an empty list evaluates all resources of the resource server.
+type PolicyEvaluationRequest struct {
+	// Context holds the attributes available to the policies, e.g. {"attributes": {"kc.client.network.ip_address": "127.0.0.1"}}
+	Context      map[string]map[string]string `json:"context,omitempty"`
+	Resources    []ResourceRepresentation     `json:"resources,omitempty"`
+	ResourceType *string                      `json:"resourceType,omitempty"`
+	ClientID     *string                      `json:"clientId,omitempty"`
+	UserID       *string                      `json:"userId,omitempty"`
+	RoleIDs      []string                     `json:"roleIds,omitempty"`
+	Entitlements *bool                        `json:"entitlements,omitempty"`
+}
+
+// PolicyEvaluationResponse is the result of EvaluatePolicies
+type PolicyEvaluationResponse struct {
+	Results      []*EvaluationResultRepresentation `json:"results,omitempty"`
+	Entitlements *bool                             `json:"entitlements,omitempty"`
+	Status       *DecisionEffect                   `json:"status,omitempty"`
+	RPT          map[string]any                    `json:"rpt,omitempty"`
+}
+
+// EvaluationResultRepresentation is the decision for a single resource of a policy evaluation
+type EvaluationResultRepresentation struct {
+	Resource      *ResourceRepresentation       `json:"resource,omitempty"`
+	Scopes        []*ScopeRepresentation        `json:"scopes,omitempty"`
+	Policies      []*PolicyResultRepresentation `json:"policies,omitempty"`
+	Status        *DecisionEffect               `json:"status,omitempty"`
+	AllowedScopes []*ScopeRepresentation        `json:"allowedScopes,omitempty"`
+	DeniedScopes  []*ScopeRepresentation        `json:"deniedScopes,omitempty"`
+}
+
+// PolicyResultRepresentation is the decision of a single policy, or permission, of a policy evaluation
+type PolicyResultRepresentation struct {
+	Policy             *PolicyRepresentation         `json:"policy,omitempty"`
+	Status             *DecisionEffect               `json:"status,omitempty"`
+	AssociatedPolicies []*PolicyResultRepresentation `json:"associatedPolicies,omitempty"`
+	Scopes             []string                      `json:"scopes,omitempty"`
+	ResourceType       *string                       `json:"resourceType,omitempty"`
+}
+
 // ProtocolMapperRepresentation represents....
 type ProtocolMapperRepresentation struct {
 	Config          map[string]string `json:"config,omitempty"`
@@ -753,6 +956,8 @@ type RealmRepresentation struct {
 	ActionTokenGeneratedByUserLifespan                        *int                             `json:"actionTokenGeneratedByUserLifespan,omitempty"`
 	AdminEventsDetailsEnabled                                 *bool                            `json:"adminEventsDetailsEnabled,omitempty"`
 	AdminEventsEnabled                                        *bool                            `json:"adminEventsEnabled,omitempty"`
+	AdminPermissionsClient                                    *Client                          `json:"adminPermissionsClient,omitempty"`
+	AdminPermissionsEnabled                                   *bool                            `json:"adminPermissionsEnabled,omitempty"`
 	AdminTheme                                                *string                          `json:"adminTheme,omitempty"`
 	Attributes                                                map[string]string                `json:"attributes,omitempty"`
 	AuthenticationFlows                                       []any                            `json:"authenticationFlows,omitempty"`
@@ -873,6 +1078,14 @@ type RealmRepresentation struct {
 	WaitIncrementSeconds                                      *int                             `json:"waitIncrementSeconds,omitempty"`
 }
 
+// AuthenticatorProviderRepresentation represents an authenticator, form, form action or client authenticator provider
+type AuthenticatorProviderRepresentation struct {
+	ID             *string `json:"id,omitempty"`
+	DisplayName    *string `json:"displayName,omitempty"`
+	Description    *string `json:"description,omitempty"`
+	SupportsSecret *bool   `json:"supportsSecret,omitempty"`
+}
+
 // AuthenticationFlowRepresentation represents an authentication flow of a realm
 type AuthenticationFlowRepresentation struct {
 	Alias                    *string                                 `json:"alias,omitempty"`
@@ -1105,6 +1318,33 @@ type RequestingPartyPermissionDecision struct {
 	Result *bool `json:"result,omitempty"`
 }
 
+// UserConsentRepresentation represents the consent a user granted to a client
+// v26: https://www.keycloak.org/docs-api/latest/rest-api/index.html#UserConsentRepresentation
+type UserConsentRepresentation struct {
+	ClientID            *string                       `json:"clientId,omitempty"`
+	GrantedClientScopes []string                      `json:"grantedClientScopes,omitempty"`
+	CreatedDate         *int64                        `json:"createdDate,omitempty"`
+	LastUpdatedDate     *int64                        `json:"lastUpdatedDate,omitempty"`
+	AdditionalGrants    []*UserConsentAdditionalGrant `json:"additionalGrants,omitempty"`
+}
+
+// UserConsentAdditionalGrant represents a grant of a client which is not part of the consent,
+// e.g. an offline token which has the key "Offline Token"
+type UserConsentAdditionalGrant struct {
+	Client *string `json:"client,omitempty"`
+	Key    *string `json:"key,omitempty"`
+}
+
+// ImpersonationRepresentation is the result of impersonating a user
+type ImpersonationRepresentation struct {
+	// SameRealm is true if the impersonated user belongs to the realm of the admin
+	SameRealm *bool `json:"sameRealm,omitempty"`
+	// Redirect is the account console url of the impersonated user
+	Redirect *string `json:"redirect,omitempty"`
+	// Cookies contains the session cookies of the impersonated user issued by Keycloak
+	Cookies []*http.Cookie `json:"-"`
+}
+
 // UserSessionRepresentation represents a list of user's sessions
 type UserSessionRepresentation struct {
 	Clients    map[string]string `json:"clients,omitempty"`
@@ -1287,12 +1527,13 @@ type GetPolicyParams struct {
 
 // GetPermissionParams represents the optional parameters for getting permissions
 type GetPermissionParams struct {
-	First    *int    `json:"first,string,omitempty"`
-	Max      *int    `json:"max,string,omitempty"`
-	Name     *string `json:"name,omitempty"`
-	Resource *string `json:"resource,omitempty"`
-	Scope    *string `json:"scope,omitempty"`
-	Type     *string `json:"type,omitempty"`
+	First        *int    `json:"first,string,omitempty"`
+	Max          *int    `json:"max,string,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Resource     *string `json:"resource,omitempty"`
+	ResourceType *string `json:"resourceType,omitempty"`
+	Scope        *string `json:"scope,omitempty"`
+	Type         *string `json:"type,omitempty"`
 }
 
 // GetUsersByRoleParams represents the optional parameters for getting users by role
@@ -1449,6 +1690,7 @@ type GetEventsParams struct {
 
 // EventRepresentation is a representation of a Event
 type EventRepresentation struct {
+	ID        *string           `json:"id,omitempty"`
 	Time      int64             `json:"time,omitempty"`
 	Type      *string           `json:"type,omitempty"`
 	RealmID   *string           `json:"realmId,omitempty"`
@@ -1456,6 +1698,7 @@ type EventRepresentation struct {
 	UserID    *string           `json:"userId,omitempty"`
 	SessionID *string           `json:"sessionId,omitempty"`
 	IPAddress *string           `json:"ipAddress,omitempty"`
+	Error     *string           `json:"error,omitempty"`
 	Details   map[string]string `json:"details,omitempty"`
 }
 
@@ -1467,8 +1710,8 @@ type GetAdminEventsParams struct {
 	AuthUser       *string  `json:"authUser,omitempty"`
 	DateFrom       *string  `json:"dateFrom,omitempty"`
 	DateTo         *string  `json:"dateTo,omitempty"`
-	First          *int32   `json:"first,omitempty"`
-	Max            *int32   `json:"max,omitempty"`
+	First          *int32   `json:"first,string,omitempty"`
+	Max            *int32   `json:"max,string,omitempty"`
 	OperationTypes []string `json:"operationTypes,omitempty"`
 	ResourcePath   *string  `json:"resourcePath,omitempty"`
 	ResourceTypes  []string `json:"resourceTypes,omitempty"`
@@ -1476,6 +1719,7 @@ type GetAdminEventsParams struct {
 
 // AdminEventRepresentation is a representation of an Admin Event
 type AdminEventRepresentation struct {
+	ID             *string                              `json:"id,omitempty"`
 	Time           int64                                `json:"time,omitempty"`
 	OperationType  *string                              `json:"operationType,omitempty"`
 	RealmID        *string                              `json:"realmId,omitempty"`
@@ -1483,6 +1727,8 @@ type AdminEventRepresentation struct {
 	ResourcePath   *string                              `json:"resourcePath,omitempty"`
 	AuthDetails    *AdminEventAuthDetailsRepresentation `json:"authDetails,omitempty"`
 	Representation *string                              `json:"representation,omitempty"`
+	Error          *string                              `json:"error,omitempty"`
+	Details        map[string]string                    `json:"details,omitempty"`
 }
 
 // AdminEventAuthDetailsRepresentation is a representation of an Admin Event Details
@@ -1530,6 +1776,12 @@ type BruteForceStatus struct {
 	LastFailure   *int    `json:"lastFailure,omitempty"`
 }
 
+// LockedUser is a user locked by the brute force detection together with its brute force status
+type LockedUser struct {
+	User             *User             `json:"user,omitempty"`
+	BruteForceStatus *BruteForceStatus `json:"bruteForceStatus,omitempty"`
+}
+
 // RequiredActionProviderRepresentation is a representation of required actions
 // v15: https://www.keycloak.org/docs-api/15.0/rest-api/index.html#_requiredactionproviderrepresentation
 type RequiredActionProviderRepresentation struct {
@@ -1542,6 +1794,16 @@ type RequiredActionProviderRepresentation struct {
 	ProviderID    *string           `json:"providerId,omitempty"`
 }
 
+// RequiredActionConfigRepresentation is a representation of the configuration of a required action
+type RequiredActionConfigRepresentation struct {
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// RequiredActionConfigInfoRepresentation describes the configuration properties supported by a required action
+type RequiredActionConfigInfoRepresentation struct {
+	Properties []ConfigPropertyRepresentation `json:"properties,omitempty"`
+}
+
 // UnregisteredRequiredActionProviderRepresentation is a representation of unregistered required actions.
 type UnregisteredRequiredActionProviderRepresentation struct {
 	Name       *string `json:"name,omitempty"`
@@ -1707,6 +1969,196 @@ type OrganizationInvitationRepresentation struct {
 	InviteLink     *string           `json:"inviteLink,omitempty"`
 }
 
+// ClientInitialAccessCreatePresentation represents the parameters for creating an initial access token
+type ClientInitialAccessCreatePresentation struct {
+	Expiration *int32   `json:"expiration,omitempty"`
+	Count      *int32   `json:"count,omitempty"`
+	WebOrigins []string `json:"webOrigins,omitempty"`
+}
+
+// ClientInitialAccessPresentation represents an initial access token used for client registration
+type ClientInitialAccessPresentation struct {
+	ID             *string `json:"id,omitempty"`
+	Token          *string `json:"token,omitempty"`
+	Timestamp      *int32  `json:"timestamp,omitempty"`
+	Expiration     *int32  `json:"expiration,omitempty"`
+	Count          *int32  `json:"count,omitempty"`
+	RemainingCount *int32  `json:"remainingCount,omitempty"`
+}
+
+// OIDCClientRepresentation is the client metadata used by the OpenID Connect Dynamic Client Registration endpoint
+// https://datatracker.ietf.org/doc/html/rfc7591#section-2
+type OIDCClientRepresentation struct {
+	ClientID                              *string       `json:"client_id,omitempty"`
+	ClientSecret                          *string       `json:"client_secret,omitempty"`
+	ClientIDIssuedAt                      *int64        `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt                 *int64        `json:"client_secret_expires_at,omitempty"`
+	RegistrationClientURI                 *string       `json:"registration_client_uri,omitempty"`
+	RegistrationAccessToken               *string       `json:"registration_access_token,omitempty"`
+	ClientName                            *string       `json:"client_name,omitempty"`
+	ClientURI                             *string       `json:"client_uri,omitempty"`
+	LogoURI                               *string       `json:"logo_uri,omitempty"`
+	PolicyURI                             *string       `json:"policy_uri,omitempty"`
+	TosURI                                *string       `json:"tos_uri,omitempty"`
+	Contacts                              []string      `json:"contacts,omitempty"`
+	RedirectURIs                          []string      `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs                []string      `json:"post_logout_redirect_uris,omitempty"`
+	GrantTypes                            []string      `json:"grant_types,omitempty"`
+	ResponseTypes                         []string      `json:"response_types,omitempty"`
+	Scope                                 *string       `json:"scope,omitempty"`
+	ApplicationType                       *string       `json:"application_type,omitempty"`
+	SubjectType                           *string       `json:"subject_type,omitempty"`
+	SectorIdentifierURI                   *string       `json:"sector_identifier_uri,omitempty"`
+	TokenEndpointAuthMethod               *string       `json:"token_endpoint_auth_method,omitempty"`
+	TokenEndpointAuthSigningAlg           *string       `json:"token_endpoint_auth_signing_alg,omitempty"`
+	JWKSURI                               *string       `json:"jwks_uri,omitempty"`
+	JWKS                                  *CertResponse `json:"jwks,omitempty"`
+	SoftwareID                            *string       `json:"software_id,omitempty"`
+	SoftwareVersion                       *string       `json:"software_version,omitempty"`
+	IDTokenSignedResponseAlg              *string       `json:"id_token_signed_response_alg,omitempty"`
+	IDTokenEncryptedResponseAlg           *string       `json:"id_token_encrypted_response_alg,omitempty"`
+	IDTokenEncryptedResponseEnc           *string       `json:"id_token_encrypted_response_enc,omitempty"`
+	UserinfoSignedResponseAlg             *string       `json:"userinfo_signed_response_alg,omitempty"`
+	UserinfoEncryptedResponseAlg          *string       `json:"userinfo_encrypted_response_alg,omitempty"`
+	UserinfoEncryptedResponseEnc          *string       `json:"userinfo_encrypted_response_enc,omitempty"`
+	RequestObjectSigningAlg               *string       `json:"request_object_signing_alg,omitempty"`
+	RequestURIs                           []string      `json:"request_uris,omitempty"`
+	DefaultMaxAge                         *int          `json:"default_max_age,omitempty"`
+	RequireAuthTime                       *bool         `json:"require_auth_time,omitempty"`
+	DefaultACRValues                      []string      `json:"default_acr_values,omitempty"`
+	FrontchannelLogoutURI                 *string       `json:"frontchannel_logout_uri,omitempty"`
+	FrontchannelLogoutSessionRequired     *bool         `json:"frontchannel_logout_session_required,omitempty"`
+	BackchannelLogoutURI                  *string       `json:"backchannel_logout_uri,omitempty"`
+	BackchannelLogoutSessionRequired      *bool         `json:"backchannel_logout_session_required,omitempty"`
+	BackchannelLogoutRevokeOfflineTokens  *bool         `json:"backchannel_logout_revoke_offline_tokens,omitempty"`
+	TLSClientCertificateBoundAccessTokens *bool         `json:"tls_client_certificate_bound_access_tokens,omitempty"`
+	TLSClientAuthSubjectDN                *string       `json:"tls_client_auth_subject_dn,omitempty"`
+}
+
+// CertificateRepresentation is a representation of a client certificate and its key pair
+type CertificateRepresentation struct {
+	PrivateKey  *string `json:"privateKey,omitempty"`
+	PublicKey   *string `json:"publicKey,omitempty"`
+	Certificate *string `json:"certificate,omitempty"`
+	Kid         *string `json:"kid,omitempty"`
+	JWKS        *string `json:"jwks,omitempty"`
+}
+
+// KeystoreFormat is an enum type for the keystore formats accepted by the client certificate endpoints
+type KeystoreFormat string
+
+// KeystoreFormat values
+const (
+	KeystoreFormatJKS            KeystoreFormat = "JKS"
+	KeystoreFormatPKCS12         KeystoreFormat = "PKCS12"
+	KeystoreFormatCertificatePEM KeystoreFormat = "Certificate PEM"
+	KeystoreFormatPublicKeyPEM   KeystoreFormat = "Public Key PEM"
+	KeystoreFormatJWKS           KeystoreFormat = "JSON Web Key Set"
+)
+
+// ClientKeyStoreConfig represents the keystore options used when downloading a client certificate
+type ClientKeyStoreConfig struct {
+	RealmCertificate *bool           `json:"realmCertificate,omitempty"`
+	StorePassword    *string         `json:"storePassword,omitempty"`
+	KeyPassword      *string         `json:"keyPassword,omitempty"`
+	KeyAlias         *string         `json:"keyAlias,omitempty"`
+	RealmAlias       *string         `json:"realmAlias,omitempty"`
+	Format           *KeystoreFormat `json:"format,omitempty"`
+	KeySize          *int32          `json:"keySize,omitempty"`
+	Validity         *int32          `json:"validity,omitempty"`
+}
+
+// UploadClientCertificateParams represents the keystore options used when uploading a client certificate
+type UploadClientCertificateParams struct {
+	KeystoreFormat *KeystoreFormat `json:"keystoreFormat,omitempty"`
+	KeyAlias       *string         `json:"keyAlias,omitempty"`
+	KeyPassword    *string         `json:"keyPassword,omitempty"`
+	StorePassword  *string         `json:"storePassword,omitempty"`
+}
+
+// FormData returns form data for a given UploadClientCertificateParams
+func (v *UploadClientCertificateParams) FormData() map[string]string {
+	m, _ := json.Marshal(v)
+	var res map[string]string
+	_ = json.Unmarshal(m, &res)
+	return res
+}
+
+// ClientSecretRotationInfo represents the state of a client secret rotation.
+// All times are Unix timestamps in seconds as stored by Keycloak in the client attributes.
+type ClientSecretRotationInfo struct {
+	Secret                      *CredentialRepresentation `json:"secret,omitempty"`
+	SecretCreationTime          *int64                    `json:"secretCreationTime,omitempty"`
+	SecretExpirationTime        *int64                    `json:"secretExpirationTime,omitempty"`
+	RotatedSecret               *CredentialRepresentation `json:"rotatedSecret,omitempty"`
+	RotatedSecretCreationTime   *int64                    `json:"rotatedSecretCreationTime,omitempty"`
+	RotatedSecretExpirationTime *int64                    `json:"rotatedSecretExpirationTime,omitempty"`
+}
+
+// EvaluateScopesParams represents the optional parameters for evaluating the client scopes of a client
+type EvaluateScopesParams struct {
+	Audience *string `json:"audience,omitempty"`
+	Scope    *string `json:"scope,omitempty"`
+	UserID   *string `json:"userId,omitempty"`
+}
+
+// ProtocolMapperEvaluationRepresentation represents a protocol mapper used when generating tokens for a client
+type ProtocolMapperEvaluationRepresentation struct {
+	MapperID       *string `json:"mapperId,omitempty"`
+	MapperName     *string `json:"mapperName,omitempty"`
+	ContainerID    *string `json:"containerId,omitempty"`
+	ContainerName  *string `json:"containerName,omitempty"`
+	ContainerType  *string `json:"containerType,omitempty"`
+	ProtocolMapper *string `json:"protocolMapper,omitempty"`
+}
+
+// RealmEventsConfigRepresentation is a representation of the events configuration of a realm
+type RealmEventsConfigRepresentation struct {
+	EventsEnabled             *bool    `json:"eventsEnabled,omitempty"`
+	EventsExpiration          *int64   `json:"eventsExpiration,omitempty"`
+	EventsListeners           []string `json:"eventsListeners,omitempty"`
+	EnabledEventTypes         []string `json:"enabledEventTypes,omitempty"`
+	AdminEventsEnabled        *bool    `json:"adminEventsEnabled,omitempty"`
+	AdminEventsDetailsEnabled *bool    `json:"adminEventsDetailsEnabled,omitempty"`
+}
+
+// EventCursor is the position of an event tail. It can be persisted to resume tailing later on.
+type EventCursor struct {
+	// Time of the most recent event seen, in milliseconds since the epoch
+	Time int64 `json:"time,omitempty"`
+	// Hashes identify the events seen at Time. The id of an event is used if Keycloak returns one,
+	// otherwise a hash of its content.
+	Hashes []string `json:"hashes,omitempty"`
+}
+
+// TailEventsOptions represents the options for tailing events
+type TailEventsOptions struct {
+	// Cursor to resume from. If nil, tailing starts after the most recent event.
+	Cursor *EventCursor
+	// PollInterval between two polls, defaults to 5 seconds
+	PollInterval time.Duration
+	// PageSize of a single request, defaults to 100
+	PageSize int32
+	// MinBackoff is the initial delay after a failed poll, defaults to 1 second
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay after consecutive failed polls, defaults to 1 minute
+	MaxBackoff time.Duration
+	// OnError is called with every error which occurs while polling
+	OnError func(error)
+}
+
+// TailedEvent is an event returned by TailEvents together with the cursor pointing at it
+type TailedEvent struct {
+	Event  *EventRepresentation
+	Cursor EventCursor
+}
+
+// TailedAdminEvent is an admin event returned by TailAdminEvents together with the cursor pointing at it
+type TailedAdminEvent struct {
+	Event  *AdminEventRepresentation
+	Cursor EventCursor
+}
+
 // prettyStringStruct returns struct formatted into pretty string
 func prettyStringStruct(t any) string {
 	json, err := json.MarshalIndent(t, "", "\t")
@@ -1774,6 +2226,34 @@ const (
 	UnmanagedAttributePolicyAdminEdit UnmanagedAttributePolicy = "ADMIN_EDIT"
 )
 
+// UserProfileMetadata represents the user profile of a user as seen by the admin,
+// as returned by GetUserProfileMetadata and GetUserByIDWithProfileMetadata
+type UserProfileMetadata struct {
+	Attributes []*UserProfileAttributeMetadata      `json:"attributes,omitempty"`
+	Groups     []*UserProfileAttributeGroupMetadata `json:"groups,omitempty"`
+}
+
+// UserProfileAttributeMetadata represents a managed attribute of the user profile
+type UserProfileAttributeMetadata struct {
+	Name         *string                   `json:"name,omitempty"`
+	DisplayName  *string                   `json:"displayName,omitempty"`
+	Required     *bool                     `json:"required,omitempty"`
+	ReadOnly     *bool                     `json:"readOnly,omitempty"`
+	Annotations  map[string]any            `json:"annotations,omitempty"`
+	Validators   map[string]map[string]any `json:"validators,omitempty"`
+	Group        *string                   `json:"group,omitempty"`
+	MultiValued  *bool                     `json:"multivalued,omitempty"`
+	DefaultValue *string                   `json:"defaultValue,omitempty"`
+}
+
+// UserProfileAttributeGroupMetadata represents an attribute group of the user profile
+type UserProfileAttributeGroupMetadata struct {
+	Name               *string        `json:"name,omitempty"`
+	DisplayHeader      *string        `json:"displayHeader,omitempty"`
+	DisplayDescription *string        `json:"displayDescription,omitempty"`
+	Annotations        map[string]any `json:"annotations,omitempty"`
+}
+
 // Stringer implementations for all struct types
 func (v *CertResponseKey) String() string                           { return prettyStringStruct(v) }
 func (v *CertResponse) String() string                              { return prettyStringStruct(v) }
@@ -1878,3 +2358,43 @@ func (v *UserProfileAttributeRequired) String() string              { return pre
 func (v *UserProfileAttributeSelector) String() string              { return prettyStringStruct(v) }
 func (v *UserProfileConfig) String() string                         { return prettyStringStruct(v) }
 func (v *UserProfileGroup) String() string                          { return prettyStringStruct(v) }
+func (v *ClientInitialAccessCreatePresentation) String() string     { return prettyStringStruct(v) }
+func (v *ClientInitialAccessPresentation) String() string           { return prettyStringStruct(v) }
+func (v *OIDCClientRepresentation) String() string                  { return prettyStringStruct(v) }
+func (v *CertificateRepresentation) String() string                 { return prettyStringStruct(v) }
+func (v *ClientKeyStoreConfig) String() string                      { return prettyStringStruct(v) }
+func (v *UploadClientCertificateParams) String() string             { return prettyStringStruct(v) }
+func (v *ClientSecretRotationInfo) String() string                  { return prettyStringStruct(v) }
+func (v *EvaluateScopesParams) String() string                      { return prettyStringStruct(v) }
+func (v *ProtocolMapperEvaluationRepresentation) String() string    { return prettyStringStruct(v) }
+func (v *RealmEventsConfigRepresentation) String() string           { return prettyStringStruct(v) }
+func (v *EventCursor) String() string                               { return prettyStringStruct(v) }
+func (v *ImpersonationRepresentation) String() string               { return prettyStringStruct(v) }
+func (v *UserConsentRepresentation) String() string                 { return prettyStringStruct(v) }
+func (v *UserConsentAdditionalGrant) String() string                { return prettyStringStruct(v) }
+func (v *UserProfileMetadata) String() string                       { return prettyStringStruct(v) }
+func (v *UserProfileAttributeMetadata) String() string              { return prettyStringStruct(v) }
+func (v *UserProfileAttributeGroupMetadata) String() string         { return prettyStringStruct(v) }
+func (v *LockedUser) String() string                                { return prettyStringStruct(v) }
+func (v *AuthenticatorProviderRepresentation) String() string       { return prettyStringStruct(v) }
+func (v *RequiredActionConfigRepresentation) String() string        { return prettyStringStruct(v) }
+func (v *RequiredActionConfigInfoRepresentation) String() string    { return prettyStringStruct(v) }
+func (v *PolicyEvaluationRequest) String() string                   { return prettyStringStruct(v) }
+func (v *PolicyEvaluationResponse) String() string                  { return prettyStringStruct(v) }
+func (v *EvaluationResultRepresentation) String() string            { return prettyStringStruct(v) }
+func (v *PolicyResultRepresentation) String() string                { return prettyStringStruct(v) }
+func (v *TypedPolicyBase) String() string                           { return prettyStringStruct(v) }
+func (v *RolePolicy) String() string                                { return prettyStringStruct(v) }
+func (v *UserPolicy) String() string                                { return prettyStringStruct(v) }
+func (v *GroupPolicy) String() string                               { return prettyStringStruct(v) }
+func (v *ClientPolicy) String() string                              { return prettyStringStruct(v) }
+func (v *TimePolicy) String() string                                { return prettyStringStruct(v) }
+func (v *AggregatePolicy) String() string                           { return prettyStringStruct(v) }
+func (v *GenericPolicy) String() string                             { return prettyStringStruct(v) }
+func (v *RegexPolicyRepresentation) String() string                 { return prettyStringStruct(v) }
+func (v *ClientScopePolicyRepresentation) String() string           { return prettyStringStruct(v) }
+func (v *ClientScopeDefinition) String() string                     { return prettyStringStruct(v) }
+func (v *RegexPolicy) String() string                               { return prettyStringStruct(v) }
+func (v *ClientScopePolicy) String() string                         { return prettyStringStruct(v) }
+func (v *AuthorizationSchema) String() string                       { return prettyStringStruct(v) }
+func (v *AuthorizationSchemaResourceType) String() string           { return prettyStringStruct(v) }
@@ -0,0 +1,115 @@
+package gocloak
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// newTypedPolicy returns an empty typed policy of the given type
+func newTypedPolicy(policyType string) TypedPolicy {
+	switch policyType {
+	case "role":
+		return &RolePolicy{}
+	case "user":
+		return &UserPolicy{}
+	case "group":
+		return &GroupPolicy{}
+	case "client":
+		return &ClientPolicy{}
+	case "time":
+		return &TimePolicy{}
+	case "regex":
+		return &RegexPolicy{}
+	case "client-scope":
+		return &ClientScopePolicy{}
+	case "aggregate":
+		return &AggregatePolicy{}
+	}
+	return &GenericPolicy{}
+}
+
+// DecodePolicyRepresentation converts a policy returned by GetPolicies into the typed policy of its type.
+// GetPolicies returns the settings of a policy in its config, which only contains ids, so e.g. the paths of
+// the groups of a group policy are not set. The policies of an aggregate policy are not part of the config either,
+// use GetAuthorizationPolicyAssociatedPolicies or GetTypedPolicy to get them.
+// Policies of types without a typed representation are returned as *GenericPolicy.
+func DecodePolicyRepresentation(policy *PolicyRepresentation) (TypedPolicy, error) {
+	base := TypedPolicyBase{
+		ID:               policy.ID,
+		Name:             policy.Name,
+		Description:      policy.Description,
+		Type:             policy.Type,
+		Logic:            policy.Logic,
+		DecisionStrategy: policy.DecisionStrategy,
+		Owner:            policy.Owner,
+	}
+	config := policy.Config
+
+	// unmarshalConfig decodes a config entry holding JSON, missing entries are ignored
+	unmarshalConfig := func(key string, v any) error {
+		value, ok := config[key]
+		if !ok || value == "" {
+			return nil
+		}
+		if err := json.Unmarshal([]byte(value), v); err != nil {
+			return fmt.Errorf("could not decode %s of %s policy %s: %w", key, PString(policy.Type), PString(policy.Name), err)
+		}
+		return nil
+	}
+	// configP returns a pointer to a config entry or nil if it is not set
+	configP := func(key string) *string {
+		if value, ok := config[key]; ok && value != "" {
+			return &value
+		}
+		return nil
+	}
+
+	typed := newTypedPolicy(PString(policy.Type))
+	*typed.Base() = base
+
+	var err error
+	switch p := typed.(type) {
+	case *RolePolicy:
+		err = unmarshalConfig("roles", &p.Roles)
+		if fetchRoles := configP("fetchRoles"); fetchRoles != nil {
+			p.FetchRoles = BoolP(*fetchRoles == "true")
+		}
+	case *UserPolicy:
+		err = unmarshalConfig("users", &p.Users)
+	case *GroupPolicy:
+		err = unmarshalConfig("groups", &p.Groups)
+		p.GroupsClaim = configP("groupsClaim")
+	case *ClientPolicy:
+		err = unmarshalConfig("clients", &p.Clients)
+	case *TimePolicy:
+		p.NotBefore = configP("nbf")
+		p.NotOnOrAfter = configP("noa")
+		p.DayMonth = configP("dayMonth")
+		p.DayMonthEnd = configP("dayMonthEnd")
+		p.Month = configP("month")
+		p.MonthEnd = configP("monthEnd")
+		p.Year = configP("year")
+		p.YearEnd = configP("yearEnd")
+		p.Hour = configP("hour")
+		p.HourEnd = configP("hourEnd")
+		p.Minute = configP("minute")
+		p.MinuteEnd = configP("minuteEnd")
+	case *RegexPolicy:
+		p.TargetClaim = configP("targetClaim")
+		p.Pattern = configP("pattern")
+		if targetContextAttributes := configP("targetContextAttributes"); targetContextAttributes != nil {
+			p.TargetContextAttributes = BoolP(*targetContextAttributes == "true")
+		}
+	case *ClientScopePolicy:
+		err = unmarshalConfig("clientScopes", &p.ClientScopes)
+	case *AggregatePolicy:
+		p.Policies = policy.Policies
+	case *GenericPolicy:
+		p.Config = config
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,138 @@
+package gocloak_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+func TestDecodePolicyRepresentation(t *testing.T) {
+	policy, err := gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		ID:    gocloak.StringP("p1"),
+		Name:  gocloak.StringP("roles"),
+		Type:  gocloak.StringP("role"),
+		Logic: gocloak.Ptr(gocloak.Negative),
+		Config: map[string]string{
+			"roles":      `[{"id":"r1","required":true},{"id":"r2","required":false}]`,
+			"fetchRoles": "true",
+		},
+	})
+	require.NoError(t, err)
+	rolePolicy, ok := policy.(*gocloak.RolePolicy)
+	require.True(t, ok)
+	assert.Equal(t, "role", rolePolicy.PolicyType())
+	assert.Equal(t, "p1", gocloak.PString(rolePolicy.ID))
+	assert.Equal(t, gocloak.Negative, *rolePolicy.Logic)
+	assert.True(t, gocloak.PBool(rolePolicy.FetchRoles))
+	assert.Equal(t, []gocloak.RoleDefinition{
+		{ID: gocloak.StringP("r1"), Required: gocloak.BoolP(true)},
+		{ID: gocloak.StringP("r2"), Required: gocloak.BoolP(false)},
+	}, rolePolicy.Roles)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type: gocloak.StringP("group"),
+		Config: map[string]string{
+			"groups":      `[{"id":"g1","extendChildren":true}]`,
+			"groupsClaim": "groups",
+		},
+	})
+	require.NoError(t, err)
+	groupPolicy, ok := policy.(*gocloak.GroupPolicy)
+	require.True(t, ok)
+	assert.Equal(t, "groups", gocloak.PString(groupPolicy.GroupsClaim))
+	assert.Equal(t, []gocloak.GroupDefinition{
+		{ID: gocloak.StringP("g1"), ExtendChildren: gocloak.BoolP(true)},
+	}, groupPolicy.Groups)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type:   gocloak.StringP("user"),
+		Config: map[string]string{"users": `["u1","u2"]`},
+	})
+	require.NoError(t, err)
+	userPolicy, ok := policy.(*gocloak.UserPolicy)
+	require.True(t, ok)
+	assert.Equal(t, []string{"u1", "u2"}, userPolicy.Users)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type:   gocloak.StringP("client"),
+		Config: map[string]string{"clients": `["c1"]`},
+	})
+	require.NoError(t, err)
+	clientPolicy, ok := policy.(*gocloak.ClientPolicy)
+	require.True(t, ok)
+	assert.Equal(t, []string{"c1"}, clientPolicy.Clients)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type: gocloak.StringP("time"),
+		Config: map[string]string{
+			"nbf":     "2024-01-01 00:00:00",
+			"noa":     "2025-01-01 00:00:00",
+			"hour":    "8",
+			"hourEnd": "17",
+		},
+	})
+	require.NoError(t, err)
+	timePolicy, ok := policy.(*gocloak.TimePolicy)
+	require.True(t, ok)
+	assert.Equal(t, "2024-01-01 00:00:00", gocloak.PString(timePolicy.NotBefore))
+	assert.Equal(t, "2025-01-01 00:00:00", gocloak.PString(timePolicy.NotOnOrAfter))
+	assert.Equal(t, "8", gocloak.PString(timePolicy.Hour))
+	assert.Equal(t, "17", gocloak.PString(timePolicy.HourEnd))
+	assert.Nil(t, timePolicy.Month)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type: gocloak.StringP("regex"),
+		Config: map[string]string{
+			"targetClaim":             "email",
+			"pattern":                 ".*@example\\.com$",
+			"targetContextAttributes": "false",
+		},
+	})
+	require.NoError(t, err)
+	regexPolicy, ok := policy.(*gocloak.RegexPolicy)
+	require.True(t, ok)
+	assert.Equal(t, "email", gocloak.PString(regexPolicy.TargetClaim))
+	assert.Equal(t, ".*@example\\.com$", gocloak.PString(regexPolicy.Pattern))
+	assert.False(t, gocloak.PBool(regexPolicy.TargetContextAttributes))
+	assert.NotNil(t, regexPolicy.TargetContextAttributes)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type:   gocloak.StringP("client-scope"),
+		Config: map[string]string{"clientScopes": `[{"id":"s1","required":true}]`},
+	})
+	require.NoError(t, err)
+	clientScopePolicy, ok := policy.(*gocloak.ClientScopePolicy)
+	require.True(t, ok)
+	assert.Equal(t, "client-scope", clientScopePolicy.PolicyType())
+	assert.Equal(t, []gocloak.ClientScopeDefinition{
+		{ID: gocloak.StringP("s1"), Required: gocloak.BoolP(true)},
+	}, clientScopePolicy.ClientScopes)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type:             gocloak.StringP("aggregate"),
+		DecisionStrategy: gocloak.Ptr(gocloak.Unanimous),
+	})
+	require.NoError(t, err)
+	aggregatePolicy, ok := policy.(*gocloak.AggregatePolicy)
+	require.True(t, ok)
+	assert.Equal(t, gocloak.Unanimous, *aggregatePolicy.DecisionStrategy)
+
+	policy, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type:   gocloak.StringP("js"),
+		Config: map[string]string{"code": "$evaluation.grant();"},
+	})
+	require.NoError(t, err)
+	genericPolicy, ok := policy.(*gocloak.GenericPolicy)
+	require.True(t, ok)
+	assert.Equal(t, "js", genericPolicy.PolicyType())
+	assert.Equal(t, "$evaluation.grant();", genericPolicy.Config["code"])
+
+	_, err = gocloak.DecodePolicyRepresentation(&gocloak.PolicyRepresentation{
+		Type:   gocloak.StringP("user"),
+		Config: map[string]string{"users": "u1"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package gocloak
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenMinValidity is the minimum remaining lifetime of a cached token before it is renewed
+const tokenMinValidity = 10 * time.Second
+
+// ProtectionClient calls the UMA protection API of a resource server, i.e. the resource sets, permission tickets
+// and user-managed access policies below /realms/{realm}/authz/protection.
+// It authenticates with a protection API token (PAT), which it obtains with the client credentials of the
+// resource server and renews before it expires. A request which is rejected as unauthorized, e.g. because
+// the session of the PAT was revoked, is retried once with a new PAT.
+// User-managed access policies are managed by the owner of a resource, so their methods take a TokenProvider
+// returning an access token of the resource owner instead of using the PAT.
+// A ProtectionClient is safe for concurrent use.
+type ProtectionClient struct {
+	client       GoCloakIface
+	realm        string
+	clientID     string
+	clientSecret string
+
+	mu               sync.Mutex
+	pat              *JWT
+	expiresAt        time.Time
+	refreshExpiresAt time.Time
+}
+
+// NewProtectionClient returns a ProtectionClient for the resource server with the given client credentials.
+// The client must have authorization enabled and a service account.
+func NewProtectionClient(client GoCloakIface, realm, clientID, clientSecret string) *ProtectionClient {
+	return &ProtectionClient{
+		client:       client,
+		realm:        realm,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}
+}
+
+// Token returns a valid protection API token, it can be used as TokenProvider.
+func (p *ProtectionClient) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.pat != nil && now.Add(tokenMinValidity).Before(p.expiresAt) {
+		return p.pat.AccessToken, nil
+	}
+
+	var pat *JWT
+	var err error
+	if p.pat != nil && p.pat.RefreshToken != "" && now.Add(tokenMinValidity).Before(p.refreshExpiresAt) {
+		pat, err = p.client.RefreshToken(ctx, p.pat.RefreshToken, p.clientID, p.clientSecret, p.realm)
+	}
+	if pat == nil || err != nil {
+		pat, err = p.client.LoginClient(ctx, p.clientID, p.clientSecret, p.realm)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	p.pat = pat
+	p.expiresAt = now.Add(time.Duration(pat.ExpiresIn) * time.Second)
+	p.refreshExpiresAt = now.Add(time.Duration(pat.RefreshExpiresIn) * time.Second)
+
+	return pat.AccessToken, nil
+}
+
+// invalidate drops the given protection API token, so the next call of Token obtains a new one
+func (p *ProtectionClient) invalidate(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pat != nil && p.pat.AccessToken == token {
+		p.pat = nil
+	}
+}
+
+// withPAT calls the protection API with a valid protection API token and retries once if it was rejected
+func withPAT[T any](ctx context.Context, p *ProtectionClient, call func(token string) (T, error)) (T, error) {
+	token, err := p.Token(ctx)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	result, err := call(token)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusUnauthorized {
+		p.invalidate(token)
+		if token, err = p.Token(ctx); err != nil {
+			var empty T
+			return empty, err
+		}
+		return call(token)
+	}
+
+	return result, err
+}
+
+// CreateResource creates a resource set, set the owner to create it on behalf of a user
+func (p *ProtectionClient) CreateResource(ctx context.Context, resource ResourceRepresentation) (*ResourceRepresentation, error) {
+	return withPAT(ctx, p, func(token string) (*ResourceRepresentation, error) {
+		return p.client.CreateResourceClient(ctx, token, p.realm, resource)
+	})
+}
+
+// GetResource returns the resource set with the given id
+func (p *ProtectionClient) GetResource(ctx context.Context, resourceID string) (*ResourceRepresentation, error) {
+	return withPAT(ctx, p, func(token string) (*ResourceRepresentation, error) {
+		return p.client.GetResourceClient(ctx, token, p.realm, resourceID)
+	})
+}
+
+// GetResources returns the resource sets matching the given filters, e.g. owner, uri or type.
+// The resources are fetched in a single request unless params.Deep is set to false.
+func (p *ProtectionClient) GetResources(ctx context.Context, params GetResourceParams) ([]*ResourceRepresentation, error) {
+	if params.Deep == nil {
+		params.Deep = BoolP(true)
+	}
+
+	return withPAT(ctx, p, func(token string) ([]*ResourceRepresentation, error) {
+		return p.client.GetResourcesClient(ctx, token, p.realm, params)
+	})
+}
+
+// UpdateResource updates a resource set
+func (p *ProtectionClient) UpdateResource(ctx context.Context, resource ResourceRepresentation) error {
+	_, err := withPAT(ctx, p, func(token string) (struct{}, error) {
+		return struct{}{}, p.client.UpdateResourceClient(ctx, token, p.realm, resource)
+	})
+	return err
+}
+
+// DeleteResource deletes the resource set with the given id
+func (p *ProtectionClient) DeleteResource(ctx context.Context, resourceID string) error {
+	_, err := withPAT(ctx, p, func(token string) (struct{}, error) {
+		return struct{}{}, p.client.DeleteResourceClient(ctx, token, p.realm, resourceID)
+	})
+	return err
+}
+
+// CreatePermissionTicket creates a permission ticket for the requested resources and scopes
+func (p *ProtectionClient) CreatePermissionTicket(ctx context.Context, permissions []CreatePermissionTicketParams) (*PermissionTicketResponseRepresentation, error) {
+	return withPAT(ctx, p, func(token string) (*PermissionTicketResponseRepresentation, error) {
+		return p.client.CreatePermissionTicket(ctx, token, p.realm, permissions)
+	})
+}
+
+// withOwnerToken calls the user-managed access policy API with an access token of the resource owner
+func withOwnerToken[T any](ctx context.Context, owner TokenProvider, call func(token string) (T, error)) (T, error) {
+	token, err := owner(ctx)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	return call(token)
+}
+
+// CreateResourcePolicy creates a user-managed access policy for the resource with the given id on behalf of its owner
+func (p *ProtectionClient) CreateResourcePolicy(ctx context.Context, owner TokenProvider, resourceID string, policy ResourcePolicyRepresentation) (*ResourcePolicyRepresentation, error) {
+	return withOwnerToken(ctx, owner, func(token string) (*ResourcePolicyRepresentation, error) {
+		return p.client.CreateResourcePolicy(ctx, token, p.realm, resourceID, policy)
+	})
+}
+
+// GetResourcePolicy returns the user-managed access policy with the given id of the owner
+func (p *ProtectionClient) GetResourcePolicy(ctx context.Context, owner TokenProvider, policyID string) (*ResourcePolicyRepresentation, error) {
+	return withOwnerToken(ctx, owner, func(token string) (*ResourcePolicyRepresentation, error) {
+		return p.client.GetResourcePolicy(ctx, token, p.realm, policyID)
+	})
+}
+
+// GetResourcePolicies returns the user-managed access policies of the owner matching the given filters
+func (p *ProtectionClient) GetResourcePolicies(ctx context.Context, owner TokenProvider, params GetResourcePoliciesParams) ([]*ResourcePolicyRepresentation, error) {
+	return withOwnerToken(ctx, owner, func(token string) ([]*ResourcePolicyRepresentation, error) {
+		return p.client.GetResourcePolicies(ctx, token, p.realm, params)
+	})
+}
+
+// UpdateResourcePolicy updates the user-managed access policy with the given id of the owner
+func (p *ProtectionClient) UpdateResourcePolicy(ctx context.Context, owner TokenProvider, policyID string, policy ResourcePolicyRepresentation) error {
+	_, err := withOwnerToken(ctx, owner, func(token string) (struct{}, error) {
+		return struct{}{}, p.client.UpdateResourcePolicy(ctx, token, p.realm, policyID, policy)
+	})
+	return err
+}
+
+// DeleteResourcePolicy deletes the user-managed access policy with the given id of the owner
+func (p *ProtectionClient) DeleteResourcePolicy(ctx context.Context, owner TokenProvider, policyID string) error {
+	_, err := withOwnerToken(ctx, owner, func(token string) (struct{}, error) {
+		return struct{}{}, p.client.DeleteResourcePolicy(ctx, token, p.realm, policyID)
+	})
+	return err
+}
@@ -0,0 +1,80 @@
+package gocloak_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+type protectionTestClient struct {
+	gocloak.GoCloakIface
+	logins   int
+	rejected string
+}
+
+func (c *protectionTestClient) LoginClient(_ context.Context, clientID, clientSecret, realm string, _ ...string) (*gocloak.JWT, error) {
+	c.logins++
+	return &gocloak.JWT{
+		AccessToken: fmt.Sprintf("%s:%s@%s#%d", clientID, clientSecret, realm, c.logins),
+		ExpiresIn:   300,
+	}, nil
+}
+
+func (c *protectionTestClient) GetResourceClient(_ context.Context, token, _, resourceID string) (*gocloak.ResourceRepresentation, error) {
+	if token == c.rejected {
+		return nil, &gocloak.APIError{Code: http.StatusUnauthorized, Message: "401 Unauthorized"}
+	}
+	return &gocloak.ResourceRepresentation{ID: &resourceID, Owner: &gocloak.ResourceOwnerRepresentation{ID: &token}}, nil
+}
+
+func (c *protectionTestClient) GetResourcePolicy(_ context.Context, token, _, policyID string) (*gocloak.ResourcePolicyRepresentation, error) {
+	return &gocloak.ResourcePolicyRepresentation{ID: &policyID, Owner: &token}, nil
+}
+
+func TestProtectionClient(t *testing.T) {
+	client := &protectionTestClient{}
+	protection := gocloak.NewProtectionClient(client, "realm", "client", "secret")
+
+	token, err := protection.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "client:secret@realm#1", token)
+
+	resource, err := protection.GetResource(context.Background(), "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "r1", gocloak.PString(resource.ID))
+	assert.Equal(t, "client:secret@realm#1", gocloak.PString(resource.Owner.ID))
+	assert.Equal(t, 1, client.logins)
+
+	client.rejected = "client:secret@realm#1"
+	resource, err = protection.GetResource(context.Background(), "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "client:secret@realm#2", gocloak.PString(resource.Owner.ID))
+	assert.Equal(t, 2, client.logins)
+
+	client.rejected = "client:secret@realm#2"
+	_, err = protection.GetResource(context.Background(), "r1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.logins)
+
+	client.rejected = "client:secret@realm#4"
+	_, err = protection.GetResource(context.Background(), "r1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.logins)
+
+	policy, err := protection.GetResourcePolicy(context.Background(), gocloak.StaticToken("owner"), "p1")
+	require.NoError(t, err)
+	assert.Equal(t, "owner", gocloak.PString(policy.Owner), "resource policies have to be managed with the token of the owner")
+	assert.Equal(t, 3, client.logins)
+
+	_, err = protection.GetResourcePolicy(context.Background(), func(context.Context) (string, error) {
+		return "", errors.New("no owner token")
+	}, "p1")
+	require.Error(t, err)
+}
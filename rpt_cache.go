@@ -0,0 +1,295 @@
+package gocloak
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RPTCacheOptions configures an RPTCache
+type RPTCacheOptions struct {
+	// MaxEntries is the maximum number of cached RPTs, the least recently used RPTs are evicted first.
+	// Defaults to 10000.
+	MaxEntries int
+	// SweepInterval between two removals of all expired RPTs, defaults to 1 minute.
+	// Expired RPTs are never returned, the sweep only frees their memory.
+	SweepInterval time.Duration
+}
+
+// RPTCache caches requesting party tokens (RPT), so permission checks do not request a new RPT from Keycloak
+// every time. RPTs are cached per subject token and requested permissions until they or the subject token expire.
+// When permissions are requested which are not cached yet, the most recent RPT of the subject token is passed
+// as rpt parameter (incremental authorization), so the new RPT contains the permissions of both.
+// A cached RPT is not checked against Keycloak again: it stays valid after the session of the subject token was
+// revoked or the permissions of its user changed, until it expires or is removed with Evict.
+// An RPTCache is safe for concurrent use.
+type RPTCache struct {
+	client  GoCloakIface
+	realm   string
+	options RPTCacheOptions
+
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	lru       *list.List
+	latest    map[string]*rptCacheEntry
+	nextSweep time.Time
+}
+
+type rptCacheEntry struct {
+	key         string
+	subjectKey  string
+	rpt         *JWT
+	permissions []RequestingPartyPermission
+	expiresAt   time.Time
+}
+
+// rptClaims are the claims of an RPT holding the granted permissions
+type rptClaims struct {
+	jwt.RegisteredClaims
+	Authorization struct {
+		Permissions []struct {
+			ResourceID   *string  `json:"rsid,omitempty"`
+			ResourceName *string  `json:"rsname,omitempty"`
+			Scopes       []string `json:"scopes,omitempty"`
+		} `json:"permissions,omitempty"`
+	} `json:"authorization"`
+}
+
+// NewRPTCache returns an empty RPTCache for the given realm
+func NewRPTCache(client GoCloakIface, realm string, options RPTCacheOptions) *RPTCache {
+	if options.MaxEntries <= 0 {
+		options.MaxEntries = 10000
+	}
+	if options.SweepInterval <= 0 {
+		options.SweepInterval = time.Minute
+	}
+
+	return &RPTCache{
+		client:    client,
+		realm:     realm,
+		options:   options,
+		entries:   make(map[string]*list.Element),
+		lru:       list.New(),
+		latest:    make(map[string]*rptCacheEntry),
+		nextSweep: time.Now().Add(options.SweepInterval),
+	}
+}
+
+// GetRequestingPartyToken returns a cached RPT for the subject token and the permissions of the options,
+// or requests a new one with GetRequestingPartyToken. Requests with a permission ticket are not cached,
+// requests with a response mode are rejected as they do not return an RPT.
+func (c *RPTCache) GetRequestingPartyToken(ctx context.Context, token string, options RequestingPartyTokenOptions) (*JWT, error) {
+	entry, err := c.get(ctx, token, options)
+	if err != nil {
+		return nil, err
+	}
+	return entry.rpt, nil
+}
+
+// GetRequestingPartyPermissions returns the permissions granted by the RPT of GetRequestingPartyToken.
+// With incremental authorization these include the permissions of previous requests of the subject token.
+func (c *RPTCache) GetRequestingPartyPermissions(ctx context.Context, token string, options RequestingPartyTokenOptions) ([]RequestingPartyPermission, error) {
+	entry, err := c.get(ctx, token, options)
+	if err != nil {
+		return nil, err
+	}
+	return entry.permissions, nil
+}
+
+// Evict removes all cached RPTs of the subject token, e.g. after the permissions of its user changed
+func (c *RPTCache) Evict(token string) {
+	prefix := hashToken(token) + "\x00"
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(element)
+		}
+	}
+}
+
+func (c *RPTCache) get(ctx context.Context, token string, options RequestingPartyTokenOptions) (*rptCacheEntry, error) {
+	if !NilOrEmpty(options.Ticket) {
+		rpt, err := c.client.GetRequestingPartyToken(ctx, token, c.realm, options)
+		if err != nil {
+			return nil, err
+		}
+		return newRPTCacheEntry(token, rpt, time.Now())
+	}
+
+	if !NilOrEmpty(options.ResponseMode) {
+		return nil, errors.New("response mode is not supported by the RPT cache")
+	}
+
+	subjectKey, key, err := rptCacheKeys(token, options)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	entry := c.lookup(key, now)
+	latest := c.latest[subjectKey]
+	if latest != nil && latest.expired(now) {
+		c.remove(c.entries[latest.key])
+		latest = nil
+	}
+	c.mu.Unlock()
+
+	if entry != nil {
+		return entry, nil
+	}
+	if latest != nil && NilOrEmpty(options.RPT) {
+		options.RPT = &latest.rpt.AccessToken
+	}
+
+	rpt, err := c.client.GetRequestingPartyToken(ctx, token, c.realm, options)
+	if err != nil {
+		return nil, err
+	}
+	entry, err = newRPTCacheEntry(token, rpt, now)
+	if err != nil {
+		return nil, err
+	}
+	entry.key = key
+	entry.subjectKey = subjectKey
+
+	c.mu.Lock()
+	c.add(entry, now)
+	c.mu.Unlock()
+
+	return entry, nil
+}
+
+// rptCacheKeys returns the key of the subject, whose most recent RPT is used for incremental authorization,
+// and the key of the RPT, which covers all options affecting the response of Keycloak.
+// The keys start with the hash of the subject token and do not contain tokens in plain text.
+func rptCacheKeys(token string, options RequestingPartyTokenOptions) (string, string, error) {
+	subject, err := json.Marshal(RequestingPartyTokenOptions{
+		GrantType:        options.GrantType,
+		ClaimToken:       options.ClaimToken,
+		ClaimTokenFormat: options.ClaimTokenFormat,
+		Audience:         options.Audience,
+		SubjectToken:     options.SubjectToken,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	// all other options are part of the json representation except for the permissions, which are unordered
+	request, err := json.Marshal(options)
+	if err != nil {
+		return "", "", err
+	}
+	permissions := slices.Clone(options.Permissions)
+	slices.Sort(permissions)
+
+	subjectKey := hashToken(token) + "\x00" + hashToken(string(subject))
+	return subjectKey, subjectKey + "\x00" + hashToken(string(request)+"\x00"+strings.Join(permissions, "\x00")), nil
+}
+
+// lookup returns the cached RPT with the given key and drops it if it expired, c.mu must be held
+func (c *RPTCache) lookup(key string, now time.Time) *rptCacheEntry {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := element.Value.(*rptCacheEntry)
+	if entry.expired(now) {
+		c.remove(element)
+		return nil
+	}
+
+	c.lru.MoveToFront(element)
+	return entry
+}
+
+// add caches the RPT and evicts the least recently used RPTs beyond MaxEntries, c.mu must be held
+func (c *RPTCache) add(entry *rptCacheEntry, now time.Time) {
+	if element, ok := c.entries[entry.key]; ok {
+		c.remove(element)
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.latest[entry.subjectKey] = entry
+
+	if now.After(c.nextSweep) {
+		c.sweep(now)
+	}
+	for c.lru.Len() > c.options.MaxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// sweep removes all expired RPTs, c.mu must be held
+func (c *RPTCache) sweep(now time.Time) {
+	for element := c.lru.Front(); element != nil; {
+		next := element.Next()
+		if element.Value.(*rptCacheEntry).expired(now) {
+			c.remove(element)
+		}
+		element = next
+	}
+	c.nextSweep = now.Add(c.options.SweepInterval)
+}
+
+// remove drops a cached RPT, c.mu must be held
+func (c *RPTCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*rptCacheEntry)
+	delete(c.entries, entry.key)
+	if c.latest[entry.subjectKey] == entry {
+		delete(c.latest, entry.subjectKey)
+	}
+}
+
+// expired returns whether the RPT expires within tokenMinValidity
+func (e *rptCacheEntry) expired(now time.Time) bool {
+	return e.expiresAt.Before(now.Add(tokenMinValidity))
+}
+
+// newRPTCacheEntry reads the permissions from the RPT. The RPT is not verified, as it was received from Keycloak.
+// The entry expires with the RPT or the subject token, whichever expires first, so an expired subject token
+// is not granted access by a cached RPT. The subject token was accepted by Keycloak, it is not verified either.
+func newRPTCacheEntry(token string, rpt *JWT, now time.Time) (*rptCacheEntry, error) {
+	var claims rptClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(rpt.AccessToken, &claims); err != nil {
+		return nil, err
+	}
+
+	entry := &rptCacheEntry{
+		rpt:       rpt,
+		expiresAt: now.Add(time.Duration(rpt.ExpiresIn) * time.Second),
+	}
+
+	var subjectClaims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &subjectClaims); err == nil && subjectClaims.ExpiresAt != nil {
+		if expiresAt := subjectClaims.ExpiresAt.Time; expiresAt.Before(entry.expiresAt) {
+			entry.expiresAt = expiresAt
+		}
+	}
+
+	for _, permission := range claims.Authorization.Permissions {
+		entry.permissions = append(entry.permissions, RequestingPartyPermission{
+			ResourceID:   permission.ResourceID,
+			ResourceName: permission.ResourceName,
+			Scopes:       permission.Scopes,
+		})
+	}
+	return entry, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
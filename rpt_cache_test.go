@@ -0,0 +1,201 @@
+package gocloak_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+type rptCacheTestClient struct {
+	gocloak.GoCloakIface
+	expiresIn int
+	requests  []gocloak.RequestingPartyTokenOptions
+}
+
+// GetRequestingPartyToken grants the requested permissions in addition to the permissions of the previous RPT
+func (c *rptCacheTestClient) GetRequestingPartyToken(_ context.Context, _, _ string, options gocloak.RequestingPartyTokenOptions) (*gocloak.JWT, error) {
+	c.requests = append(c.requests, options)
+
+	var permissions []any
+	if options.RPT != nil {
+		claims := jwt.MapClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(*options.RPT, claims); err != nil {
+			return nil, err
+		}
+		permissions = claims["authorization"].(map[string]any)["permissions"].([]any)
+	}
+	for _, permission := range options.Permissions {
+		resource, scopes, _ := strings.Cut(permission, "#")
+		if resource == "denied" {
+			return nil, &gocloak.APIError{Code: http.StatusForbidden, Message: "403 Forbidden: access_denied"}
+		}
+		permissions = append(permissions, map[string]any{"rsname": resource, "scopes": strings.Split(scopes, ",")})
+	}
+
+	rpt, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"authorization": map[string]any{"permissions": permissions},
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		return nil, err
+	}
+	return &gocloak.JWT{AccessToken: rpt, ExpiresIn: c.expiresIn}, nil
+}
+
+func TestRPTCache(t *testing.T) {
+	client := &rptCacheTestClient{expiresIn: 300}
+	cache := gocloak.NewRPTCache(client, "realm", gocloak.RPTCacheOptions{})
+	options := gocloak.RequestingPartyTokenOptions{
+		Audience:    gocloak.StringP("resource-server"),
+		Permissions: []string{"items#view"},
+	}
+
+	rpt, err := cache.GetRequestingPartyToken(context.Background(), "token", options)
+	require.NoError(t, err)
+	cached, err := cache.GetRequestingPartyToken(context.Background(), "token", options)
+	require.NoError(t, err)
+	assert.Equal(t, rpt, cached)
+	require.Len(t, client.requests, 1)
+	assert.Nil(t, client.requests[0].RPT)
+
+	options.Permissions = []string{"documents#read"}
+	permissions, err := cache.GetRequestingPartyPermissions(context.Background(), "token", options)
+	require.NoError(t, err)
+	require.Len(t, client.requests, 2)
+	assert.Equal(t, rpt.AccessToken, gocloak.PString(client.requests[1].RPT))
+	assert.Equal(t, []gocloak.RequestingPartyPermission{
+		{ResourceName: gocloak.StringP("items"), Scopes: []string{"view"}},
+		{ResourceName: gocloak.StringP("documents"), Scopes: []string{"read"}},
+	}, permissions)
+
+	_, err = cache.GetRequestingPartyToken(context.Background(), "other", options)
+	require.NoError(t, err)
+	require.Len(t, client.requests, 3)
+	assert.Nil(t, client.requests[2].RPT)
+
+	cache.Evict("token")
+	_, err = cache.GetRequestingPartyToken(context.Background(), "token", options)
+	require.NoError(t, err)
+	require.Len(t, client.requests, 4)
+	assert.Nil(t, client.requests[3].RPT)
+
+	client.expiresIn = 5
+	options.Permissions = []string{"items#edit"}
+	_, err = cache.GetRequestingPartyToken(context.Background(), "token", options)
+	require.NoError(t, err)
+	_, err = cache.GetRequestingPartyToken(context.Background(), "token", options)
+	require.NoError(t, err)
+	require.Len(t, client.requests, 6)
+}
+
+func TestRPTCacheKey(t *testing.T) {
+	client := &rptCacheTestClient{expiresIn: 300}
+	cache := gocloak.NewRPTCache(client, "realm", gocloak.RPTCacheOptions{})
+	request := func(options gocloak.RequestingPartyTokenOptions) {
+		options.Permissions = []string{"items#view", "documents#read"}
+		_, err := cache.GetRequestingPartyToken(context.Background(), "token", options)
+		require.NoError(t, err)
+	}
+
+	request(gocloak.RequestingPartyTokenOptions{})
+	request(gocloak.RequestingPartyTokenOptions{PermissionResourceMatchingURI: gocloak.BoolP(true)})
+	request(gocloak.RequestingPartyTokenOptions{ResponseIncludeResourceName: gocloak.BoolP(false)})
+	request(gocloak.RequestingPartyTokenOptions{SubmitRequest: gocloak.BoolP(true)})
+	require.Len(t, client.requests, 4, "options affecting the response must not share cached RPTs")
+
+	_, err := cache.GetRequestingPartyToken(context.Background(), "token", gocloak.RequestingPartyTokenOptions{
+		Permissions:                   []string{"documents#read", "items#view"},
+		PermissionResourceMatchingURI: gocloak.BoolP(true),
+	})
+	require.NoError(t, err)
+	require.Len(t, client.requests, 4, "the order of the permissions must not matter")
+
+	_, err = cache.GetRequestingPartyToken(context.Background(), "token", gocloak.RequestingPartyTokenOptions{
+		Permissions:  []string{"items#view"},
+		ResponseMode: gocloak.StringP("decision"),
+	})
+	require.Error(t, err)
+	require.Len(t, client.requests, 4)
+}
+
+func TestRPTCacheSubjectTokenExpiry(t *testing.T) {
+	subjectToken := func(expiresIn time.Duration) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+		}).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return token
+	}
+	options := gocloak.RequestingPartyTokenOptions{Permissions: []string{"items#view"}}
+
+	client := &rptCacheTestClient{expiresIn: 300}
+	cache := gocloak.NewRPTCache(client, "realm", gocloak.RPTCacheOptions{})
+
+	valid := subjectToken(5 * time.Minute)
+	for range 2 {
+		_, err := cache.GetRequestingPartyToken(context.Background(), valid, options)
+		require.NoError(t, err)
+	}
+	require.Len(t, client.requests, 1)
+
+	// the subject token expires before the RPT, the cached RPT must not outlive it
+	expiring := subjectToken(5 * time.Second)
+	for range 2 {
+		_, err := cache.GetRequestingPartyToken(context.Background(), expiring, options)
+		require.NoError(t, err)
+	}
+	require.Len(t, client.requests, 3)
+}
+
+func TestRPTCacheMaxEntries(t *testing.T) {
+	client := &rptCacheTestClient{expiresIn: 300}
+	cache := gocloak.NewRPTCache(client, "realm", gocloak.RPTCacheOptions{MaxEntries: 2})
+	request := func(token string) {
+		_, err := cache.GetRequestingPartyToken(context.Background(), token, gocloak.RequestingPartyTokenOptions{
+			Permissions: []string{"items#view"},
+		})
+		require.NoError(t, err)
+	}
+
+	request("a")
+	request("b")
+	request("a")
+	require.Len(t, client.requests, 2)
+
+	// c evicts b, which is the least recently used RPT
+	request("c")
+	request("a")
+	require.Len(t, client.requests, 3)
+	request("b")
+	require.Len(t, client.requests, 4)
+	assert.Nil(t, client.requests[3].RPT, "the evicted RPT must not be used for incremental authorization")
+}
+
+func TestPolicyEnforcerWithRPTCache(t *testing.T) {
+	client := &rptCacheTestClient{expiresIn: 300}
+	enforcer := gocloak.NewPolicyEnforcer(client, "realm", "resource-server", gocloak.PolicyEnforcerConfig{
+		Paths: []gocloak.PolicyEnforcerPathConfig{
+			{Name: "items", Path: "/api/items/*", Scopes: []string{"view"}},
+			{Name: "denied", Path: "/api/denied"},
+		},
+	})
+	enforcer.SetRPTCache(gocloak.NewRPTCache(client, "realm", gocloak.RPTCacheOptions{}))
+
+	for range 2 {
+		allowed, err := enforcer.Enforce(context.Background(), "token", http.MethodGet, "/api/items/1")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	assert.Len(t, client.requests, 1)
+
+	allowed, err := enforcer.Enforce(context.Background(), "token", http.MethodGet, "/api/denied")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
@@ -1,5 +1,7 @@
 package gocloak
 
+import "context"
+
 // JWT is a JWT
 type JWT struct {
 	AccessToken      string `json:"access_token"`
@@ -12,3 +14,14 @@ type JWT struct {
 	SessionState     string `json:"session_state"`
 	Scope            string `json:"scope"`
 }
+
+// TokenProvider returns a valid access token whenever it is called.
+// It is used by long running operations which outlive a single access token.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenProvider which always returns the given token
+func StaticToken(token string) TokenProvider {
+	return func(context.Context) (string, error) {
+		return token, nil
+	}
+}
@@ -0,0 +1,268 @@
+package gocloak
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// UserProfileAttributeError is a validation error of a single user profile attribute
+type UserProfileAttributeError struct {
+	Attribute string
+	Message   string
+}
+
+// Error returns a string representation of the error
+func (e *UserProfileAttributeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Attribute, e.Message)
+}
+
+// UserProfileValidationErrors are the validation errors of a user, at most one per attribute
+type UserProfileValidationErrors []*UserProfileAttributeError
+
+// Error returns a string representation of all errors
+func (e UserProfileValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Attribute returns the error of the given attribute, or nil if the attribute is valid
+func (e UserProfileValidationErrors) Attribute(name string) *UserProfileAttributeError {
+	for _, err := range e {
+		if err.Attribute == name {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e UserProfileValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// userProfileAttributeValues returns the values of an attribute of the user.
+// The root attributes username, email, firstName and lastName are read from the fields of User.
+func userProfileAttributeValues(user *User, name string) []string {
+	var value *string
+	switch name {
+	case "username":
+		value = user.Username
+	case "email":
+		value = user.Email
+	case "firstName":
+		value = user.FirstName
+	case "lastName":
+		value = user.LastName
+	default:
+		return user.Attributes[name]
+	}
+
+	if NilOrEmpty(value) {
+		return nil
+	}
+	return []string{*value}
+}
+
+// isBlank returns true if values contains no value which is not blank
+func isBlank(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateUserUpdate checks an update of a user against the user profile metadata before it is sent with UpdateUser.
+// The metadata is typically taken from the user returned by GetUserByIDWithProfileMetadata, which is also passed
+// as current. updated must be the complete user which is sent to Keycloak.
+// It reports required attributes without a value, changes of read only attributes, multiple values
+// of single valued attributes and values rejected by the validators of the attributes, see UserProfileConfig.ValidateUser.
+// The returned error is of type UserProfileValidationErrors.
+func (m *UserProfileMetadata) ValidateUserUpdate(current, updated *User) error {
+	var errs UserProfileValidationErrors
+	for _, attribute := range m.Attributes {
+		name := PString(attribute.Name)
+		values := userProfileAttributeValues(updated, name)
+
+		switch {
+		case PBool(attribute.ReadOnly) && current != nil && values != nil &&
+			!slices.Equal(values, userProfileAttributeValues(current, name)):
+			errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: "attribute is read only"})
+		case PBool(attribute.Required) && isBlank(values):
+			errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: "attribute is required"})
+		case !PBool(attribute.MultiValued) && len(values) > 1:
+			errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: "attribute is not multivalued"})
+		default:
+			validators := make(map[string]any, len(attribute.Validators))
+			for validator, config := range attribute.Validators {
+				validators[validator] = config
+			}
+			if message := validateUserProfileValues(validators, values); message != "" {
+				errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: message})
+			}
+		}
+	}
+
+	return errs.orNil()
+}
+
+// UserProfileValidationOptions describe the context in which a user is validated against the user profile
+type UserProfileValidationOptions struct {
+	// Roles of the context, "admin" when users are managed through the admin api
+	// or "user" when users manage their own profile. Defaults to "admin".
+	Roles []string
+	// Scopes requested by the client in the "user" context. Attributes with a selector are only validated if one of
+	// their scopes is requested, and attributes required for scopes are required if one of them is requested.
+	Scopes []string
+}
+
+// ValidateUser validates a user against the user profile configuration before it is sent with CreateUser or UpdateUser,
+// e.g. to fail fast during bulk imports. It checks the required attributes, multiple values of single valued
+// attributes and the length, pattern, email and options validators. Other validators are not checked locally.
+// Keycloak trims the values before the length check unless trim-disabled is set, which is mirrored here,
+// and patterns use the Go regexp syntax, which differs from Java for advanced constructs.
+// The returned error is of type UserProfileValidationErrors.
+func (c *UserProfileConfig) ValidateUser(user *User, options UserProfileValidationOptions) error {
+	roles := options.Roles
+	if len(roles) == 0 {
+		roles = []string{"admin"}
+	}
+	admin := slices.Contains(roles, "admin")
+
+	var errs UserProfileValidationErrors
+	for _, attribute := range c.Attributes {
+		name := PString(attribute.Name)
+		if !admin && attribute.Selector != nil && len(attribute.Selector.Scopes) > 0 &&
+			!containsAny(attribute.Selector.Scopes, options.Scopes) {
+			continue
+		}
+
+		values := userProfileAttributeValues(user, name)
+		required := attribute.Required != nil &&
+			((len(attribute.Required.Roles) == 0 && len(attribute.Required.Scopes) == 0) ||
+				containsAny(attribute.Required.Roles, roles) ||
+				(!admin && containsAny(attribute.Required.Scopes, options.Scopes)))
+
+		switch {
+		case required && isBlank(values):
+			errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: "attribute is required"})
+		case !PBool(attribute.MultiValued) && len(values) > 1:
+			errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: "attribute is not multivalued"})
+		default:
+			if message := validateUserProfileValues(attribute.Validations, values); message != "" {
+				errs = append(errs, &UserProfileAttributeError{Attribute: name, Message: message})
+			}
+		}
+	}
+
+	return errs.orNil()
+}
+
+// containsAny returns true if values contains one of candidates
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if slices.Contains(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateUserProfileValues applies the validators of an attribute to its values and returns the first error message.
+// Empty values are not validated, as they are covered by the required check.
+func validateUserProfileValues(validators map[string]any, values []string) string {
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		for _, validator := range []string{"length", "pattern", "email", "options"} {
+			config, ok := validators[validator]
+			if !ok {
+				continue
+			}
+			configMap, _ := config.(map[string]any)
+			if message := validateUserProfileValue(validator, configMap, value); message != "" {
+				return message
+			}
+		}
+	}
+	return ""
+}
+
+func validateUserProfileValue(validator string, config map[string]any, value string) string {
+	switch validator {
+	case "length":
+		if fmt.Sprint(config["trim-disabled"]) != "true" {
+			value = strings.TrimSpace(value)
+		}
+		length := utf8.RuneCountInString(value)
+		if minLength, ok := configInt(config["min"]); ok && length < minLength {
+			return fmt.Sprintf("length must be at least %d", minLength)
+		}
+		if maxLength, ok := configInt(config["max"]); ok && length > maxLength {
+			return fmt.Sprintf("length must be at most %d", maxLength)
+		}
+	case "pattern":
+		pattern, _ := config["pattern"].(string)
+		expression, err := regexp.Compile("^(?:" + pattern + ")$")
+		if pattern == "" || err != nil {
+			return ""
+		}
+		if !expression.MatchString(value) {
+			if message, _ := config["error-message"].(string); message != "" {
+				return message
+			}
+			return fmt.Sprintf("value does not match pattern %s", pattern)
+		}
+	case "email":
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value || address.Name != "" {
+			return "invalid email address"
+		}
+		maxLocalLength := 64
+		if configured, ok := configInt(config["max-local-length"]); ok {
+			maxLocalLength = configured
+		}
+		if at := strings.LastIndex(value, "@"); at > maxLocalLength {
+			return "invalid email address"
+		}
+	case "options":
+		// a missing or malformed list of options is a configuration error, it does not reject values
+		switch options := config["options"].(type) {
+		case []string:
+			if !slices.Contains(options, value) {
+				return "value is not one of the allowed options"
+			}
+		case []any:
+			if !slices.ContainsFunc(options, func(option any) bool { return fmt.Sprint(option) == value }) {
+				return "value is not one of the allowed options"
+			}
+		}
+	}
+	return ""
+}
+
+// configInt returns the integer value of a validator config, which Keycloak accepts as number or string
+func configInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case string:
+		i, err := strconv.Atoi(v)
+		return i, err == nil
+	}
+	return 0, false
+}
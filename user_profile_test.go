@@ -0,0 +1,189 @@
+package gocloak_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nerzal/gocloak/v14"
+)
+
+func TestUserProfileMetadataValidateUserUpdate(t *testing.T) {
+	metadata := gocloak.UserProfileMetadata{
+		Attributes: []*gocloak.UserProfileAttributeMetadata{
+			{Name: gocloak.StringP("username"), Required: gocloak.BoolP(true), ReadOnly: gocloak.BoolP(true)},
+			{Name: gocloak.StringP("email"), Required: gocloak.BoolP(true)},
+			{Name: gocloak.StringP("firstName")},
+			{Name: gocloak.StringP("department"), Required: gocloak.BoolP(true)},
+			{Name: gocloak.StringP("phone")},
+			{Name: gocloak.StringP("tags"), MultiValued: gocloak.BoolP(true)},
+		},
+	}
+	current := &gocloak.User{
+		Username: gocloak.StringP("user"),
+		Email:    gocloak.StringP("user@localhost.com"),
+		Attributes: map[string][]string{
+			"department": {"sales"},
+		},
+	}
+
+	valid := &gocloak.User{
+		Username:  gocloak.StringP("user"),
+		Email:     gocloak.StringP("other@localhost.com"),
+		FirstName: gocloak.StringP("First"),
+		Attributes: map[string][]string{
+			"department": {"support"},
+			"tags":       {"a", "b"},
+		},
+	}
+	assert.NoError(t, metadata.ValidateUserUpdate(current, valid))
+
+	invalid := &gocloak.User{
+		Username: gocloak.StringP("renamed"),
+		Attributes: map[string][]string{
+			"department": {" "},
+			"phone":      {"1", "2"},
+		},
+	}
+	err := metadata.ValidateUserUpdate(current, invalid)
+	var errs gocloak.UserProfileValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 4)
+	assert.Equal(t, "attribute is read only", errs.Attribute("username").Message)
+	assert.Equal(t, "attribute is required", errs.Attribute("email").Message)
+	assert.Equal(t, "attribute is required", errs.Attribute("department").Message)
+	assert.Equal(t, "attribute is not multivalued", errs.Attribute("phone").Message)
+	assert.Nil(t, errs.Attribute("tags"))
+	assert.Equal(t,
+		"username: attribute is read only; email: attribute is required; "+
+			"department: attribute is required; phone: attribute is not multivalued",
+		err.Error())
+}
+
+func TestUserProfileConfigValidateUser(t *testing.T) {
+	config := gocloak.UserProfileConfig{
+		Attributes: []gocloak.UserProfileAttribute{
+			{
+				Name: gocloak.StringP("username"),
+				Validations: map[string]any{
+					"length":  map[string]any{"min": 3, "max": "8"},
+					"pattern": map[string]any{"pattern": "[a-z.]+", "error-message": "only lower case letters"},
+				},
+				Required: &gocloak.UserProfileAttributeRequired{},
+			},
+			{
+				Name:        gocloak.StringP("email"),
+				Validations: map[string]any{"email": map[string]any{}},
+				Required: &gocloak.UserProfileAttributeRequired{
+					Roles: []string{"user"},
+				},
+			},
+			{
+				Name:        gocloak.StringP("firstName"),
+				Validations: map[string]any{"length": map[string]any{"max": float64(5)}},
+				Required: &gocloak.UserProfileAttributeRequired{
+					Roles: []string{"admin"},
+				},
+			},
+			{
+				Name: gocloak.StringP("department"),
+				Validations: map[string]any{
+					"options": map[string]any{"options": []any{"sales", "support"}},
+				},
+				MultiValued: gocloak.BoolP(true),
+			},
+			{
+				Name:     gocloak.StringP("phone"),
+				Required: &gocloak.UserProfileAttributeRequired{Scopes: []string{"phone"}},
+				Selector: &gocloak.UserProfileAttributeSelector{Scopes: []string{"phone"}},
+			},
+		},
+	}
+
+	valid := &gocloak.User{
+		Username:  gocloak.StringP("john.doe"),
+		FirstName: gocloak.StringP(" John  "),
+		Attributes: map[string][]string{
+			"department": {"sales", "support"},
+		},
+	}
+	assert.NoError(t, config.ValidateUser(valid, gocloak.UserProfileValidationOptions{}))
+
+	invalid := &gocloak.User{
+		Username:  gocloak.StringP("JD"),
+		Email:     gocloak.StringP("John Doe <john@localhost.com>"),
+		FirstName: gocloak.StringP("Johnny"),
+		Attributes: map[string][]string{
+			"department": {"sales", "marketing"},
+		},
+	}
+	err := config.ValidateUser(invalid, gocloak.UserProfileValidationOptions{})
+	var errs gocloak.UserProfileValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 4)
+	assert.Equal(t, "length must be at least 3", errs.Attribute("username").Message)
+	assert.Equal(t, "invalid email address", errs.Attribute("email").Message)
+	assert.Equal(t, "length must be at most 5", errs.Attribute("firstName").Message)
+	assert.Equal(t, "value is not one of the allowed options", errs.Attribute("department").Message)
+
+	invalid.Username = gocloak.StringP("JohnDoe")
+	err = config.ValidateUser(invalid, gocloak.UserProfileValidationOptions{})
+	require.True(t, errors.As(err, &errs))
+	assert.Equal(t, "only lower case letters", errs.Attribute("username").Message)
+
+	// email is required for users, firstName only for admins, phone only if its scope is requested
+	err = config.ValidateUser(valid, gocloak.UserProfileValidationOptions{Roles: []string{"user"}})
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "attribute is required", errs.Attribute("email").Message)
+
+	err = config.ValidateUser(valid, gocloak.UserProfileValidationOptions{Roles: []string{"user"}, Scopes: []string{"phone"}})
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+	assert.Equal(t, "attribute is required", errs.Attribute("phone").Message)
+
+	valid.FirstName = nil
+	err = config.ValidateUser(valid, gocloak.UserProfileValidationOptions{})
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "attribute is required", errs.Attribute("firstName").Message)
+}
+
+func TestUserProfileConfigValidateUserOptions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		validation map[string]any
+		message    string
+	}{
+		{name: "allowed option", validation: map[string]any{"options": []string{"sales"}}},
+		{name: "missing options", validation: map[string]any{}},
+		{name: "malformed options", validation: map[string]any{"options": "sales"}},
+		{name: "other options", validation: map[string]any{"options": []string{"support"}}, message: "value is not one of the allowed options"},
+		{name: "empty options", validation: map[string]any{"options": []any{}}, message: "value is not one of the allowed options"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := gocloak.UserProfileConfig{
+				Attributes: []gocloak.UserProfileAttribute{
+					{
+						Name:        gocloak.StringP("department"),
+						Validations: map[string]any{"options": testCase.validation},
+					},
+				},
+			}
+			user := &gocloak.User{Attributes: map[string][]string{"department": {"sales"}}}
+
+			err := config.ValidateUser(user, gocloak.UserProfileValidationOptions{})
+			if testCase.message == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errs gocloak.UserProfileValidationErrors
+			require.True(t, errors.As(err, &errs))
+			assert.Equal(t, testCase.message, errs.Attribute("department").Message)
+		})
+	}
+}